@@ -28,6 +28,9 @@ var (
 	ErrTypeNotAuthorized       = "not_authorized"
 )
 
+// Tokens are refreshed before they expire, so that requests in progress don't fail
+var tokenRefreshMargin = time.Minute
+
 type AuthorizeError struct {
 	Code        int    `json:"-"`
 	ErrorType   string `json:"error"`
@@ -308,6 +311,7 @@ func (c *client) exchange(ctx context.Context, form url.Values, verifier string,
 	return t, nil
 }
 
+// Returns the current token, refreshing it first if it expires within tokenRefreshMargin.
 func (c *client) getToken(ctx context.Context) (*storage.Token, error) {
 	c.mu.Lock()
 	defer c.mu.Unlock()
@@ -316,38 +320,42 @@ func (c *client) getToken(ctx context.Context) (*storage.Token, error) {
 		return nil, nil
 	}
 
-	if !c.token.Expiry.Before(c.utils.Now()) {
+	now := c.utils.Now()
+
+	if c.token.Expiry.After(now.Add(tokenRefreshMargin)) {
 		return c.token, nil
 	}
 
 	if c.token.RefreshToken == "" {
+		if !c.token.Expiry.Before(now) {
+			return c.token, nil
+		}
+
 		return nil, &AuthorizeError{
 			ErrorType:   "refresh_failed",
 			Description: "empty refresh token",
 		}
 	}
 
-	t, err := c.refreshToken(ctx, c.token.RefreshToken)
+	t, err := c.rotateToken(ctx, c.token.RefreshToken)
 
 	if err != nil {
 		var authorizeErr *AuthorizeError
 
 		if errors.As(err, &authorizeErr) && authorizeErr.ErrorType == ErrTypeInvalidGrant {
 			c.saveToken(nil)
+
+			return nil, err
+		}
+
+		// The current token can still be used until it expires
+		if !c.token.Expiry.Before(now) {
+			return c.token, nil
 		}
 
 		return nil, err
 	}
 
-	c.token = t
-	c.saveToken(&storage.Token{
-		AccessToken:  t.AccessToken,
-		TokenType:    t.TokenType,
-		RefreshToken: t.RefreshToken,
-		ExpiresIn:    t.ExpiresIn,
-		Expiry:       t.Expiry,
-	})
-
 	return t, nil
 }
 
@@ -372,20 +380,21 @@ func (c *client) updateToken(t *storage.Token) {
 	})
 }
 
-func (c *client) tryToRefreshToken(ctx context.Context, refreshToken string) bool {
+// Refreshes the token after it was rejected by the API and returns the new token, or nil if it could not be refreshed.
+func (c *client) tryToRefreshToken(ctx context.Context, refreshToken string) *storage.Token {
 	c.mu.Lock()
 	defer c.mu.Unlock()
 
 	if c.token == nil {
-		return false
+		return nil
 	}
 
-	// must have been called by a different goroutine
+	// Already refreshed by a different goroutine
 	if c.token.RefreshToken != refreshToken {
-		return false
+		return c.token
 	}
 
-	token, err := c.refreshToken(ctx, c.token.RefreshToken)
+	token, err := c.rotateToken(ctx, c.token.RefreshToken)
 
 	if err != nil {
 		var authorizeErr *AuthorizeError
@@ -395,19 +404,65 @@ func (c *client) tryToRefreshToken(ctx context.Context, refreshToken string) boo
 			c.saveToken(nil)
 		}
 
-		return false
+		return nil
 	}
 
-	c.token = token
+	return token
+}
+
+// Exchanges the refresh token for a new token and saves it. Must be called with c.mu locked.
+//
+// Refresh tokens are rotated on each use, so the config is locked for the duration of the exchange.
+// If another process has already rotated the token, its token is used instead.
+func (c *client) rotateToken(ctx context.Context, refreshToken string) (*storage.Token, error) {
+	unlock, err := c.storage.LockConfig(ctx)
+
+	if err != nil {
+		return nil, &AuthorizeError{
+			ErrorType:   ErrTypeRefreshFailed,
+			Description: err.Error(),
+		}
+	}
+
+	defer unlock()
+
+	if _, err := c.storage.ReloadConfig(); err == nil {
+		stored := c.storage.GetProfile().Token
+
+		if stored != nil && stored.RefreshToken != "" && stored.RefreshToken != refreshToken {
+			if stored.Expiry.After(c.utils.Now().Add(tokenRefreshMargin)) {
+				c.token = &storage.Token{
+					AccessToken:  stored.AccessToken,
+					TokenType:    stored.TokenType,
+					RefreshToken: stored.RefreshToken,
+					ExpiresIn:    stored.ExpiresIn,
+					Expiry:       stored.Expiry,
+				}
+
+				return c.token, nil
+			}
+
+			// Our refresh token was already used, so only the rotated one is valid
+			refreshToken = stored.RefreshToken
+		}
+	}
+
+	t, err := c.refreshToken(ctx, refreshToken)
+
+	if err != nil {
+		return nil, err
+	}
+
+	c.token = t
 	c.saveToken(&storage.Token{
-		AccessToken:  token.AccessToken,
-		TokenType:    token.TokenType,
-		RefreshToken: token.RefreshToken,
-		ExpiresIn:    token.ExpiresIn,
-		Expiry:       token.Expiry,
+		AccessToken:  t.AccessToken,
+		TokenType:    t.TokenType,
+		RefreshToken: t.RefreshToken,
+		ExpiresIn:    t.ExpiresIn,
+		Expiry:       t.Expiry,
 	})
 
-	return true
+	return t, nil
 }
 
 func (c *client) refreshToken(ctx context.Context, token string) (*storage.Token, error) {
@@ -41,6 +41,14 @@ type Client interface {
 	// Returns a link to be used for authorization and listens for the authorization callback.
 	Authorize(ctx context.Context, callback func(error)) (*AuthorizeResponse, error)
 
+	// Starts the OAuth device authorization flow and returns the user code and the verification URL.
+	//
+	// https://datatracker.ietf.org/doc/html/rfc8628
+	AuthorizeDevice(ctx context.Context) (*DeviceAuthorizationResponse, error)
+
+	// Polls the token endpoint until the device authorization is approved, denied, or expires. The token is stored on success.
+	AwaitDeviceToken(ctx context.Context, device *DeviceAuthorizationResponse) error
+
 	// Returns the introspection response for the token.
 	//
 	// If the token is empty, the client's current token will be used.
@@ -52,6 +60,11 @@ type Client interface {
 	// Revokes the token.
 	RevokeToken(ctx context.Context, token string) error
 
+	// Returns a list of all probes currently online and their metadata, such as location and assigned tags.
+	//
+	// https://globalping.io/docs/api.globalping.io#get-/v1/probes
+	Probes(ctx context.Context) (*globalping.ProbesResponse, error)
+
 	// Returns the rate limits for the current user or IP address.
 	Limits(ctx context.Context) (*globalping.LimitsResponse, error)
 
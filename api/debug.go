@@ -0,0 +1,163 @@
+package api
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"regexp"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+)
+
+const redacted = "[REDACTED]"
+
+var (
+	debugResponseHeaders = []string{
+		"Content-Type",
+		"Retry-After",
+		"X-Credits-Consumed",
+		"X-Credits-Remaining",
+		"X-Ratelimit-Limit",
+		"X-Ratelimit-Remaining",
+		"X-Ratelimit-Reset",
+		"X-Request-Cost",
+	}
+	redactedHeaders = []string{"Authorization", "Cookie", "Proxy-Authorization", "Set-Cookie"}
+
+	secretFields      = `access_token|refresh_token|id_token|client_secret|code|code_verifier|device_code|user_code|token`
+	secretFormFieldRe = regexp.MustCompile(`(^|&)(` + secretFields + `)=[^&]*`)
+	secretJSONFieldRe = regexp.MustCompile(`"(` + secretFields + `)"(\s*:\s*)"[^"]*"`)
+)
+
+type debugTransport struct {
+	transport http.RoundTripper
+	w         io.Writer
+	bodies    bool
+	mu        sync.Mutex
+}
+
+// Returns a transport which logs the requests and responses to w, with secrets redacted. Bodies are only logged if bodies is true.
+func NewDebugTransport(transport http.RoundTripper, w io.Writer, bodies bool) http.RoundTripper {
+	return &debugTransport{
+		transport: transport,
+		w:         w,
+		bodies:    bodies,
+	}
+}
+
+func (t *debugTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	var b strings.Builder
+
+	fmt.Fprintf(&b, "> %s %s\n", req.Method, redactURL(req.URL))
+	writeDebugHeaders(&b, "> ", req.Header, nil)
+
+	if t.bodies && req.Body != nil && req.Body != http.NoBody {
+		body, err := io.ReadAll(req.Body)
+		_ = req.Body.Close()
+
+		if err != nil {
+			return nil, err
+		}
+
+		req.Body = io.NopCloser(bytes.NewReader(body))
+		writeDebugBody(&b, "> ", body)
+	}
+
+	start := time.Now()
+	res, err := t.transport.RoundTrip(req)
+	duration := time.Since(start).Milliseconds()
+
+	if err != nil {
+		fmt.Fprintf(&b, "< error after %dms: %v\n", duration, err)
+		t.write(b.String())
+
+		return nil, err
+	}
+
+	fmt.Fprintf(&b, "< %s (%dms)\n", res.Status, duration)
+	writeDebugHeaders(&b, "< ", res.Header, debugResponseHeaders)
+
+	if t.bodies && res.Body != nil {
+		body, err := io.ReadAll(res.Body)
+		_ = res.Body.Close()
+		res.Body = io.NopCloser(bytes.NewReader(body))
+
+		if err != nil {
+			fmt.Fprintf(&b, "< error reading body: %v\n", err)
+		} else {
+			writeDebugBody(&b, "< ", body)
+		}
+	}
+
+	t.write(b.String())
+
+	return res, nil
+}
+
+func (t *debugTransport) write(s string) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	_, _ = io.WriteString(t.w, s+"\n")
+}
+
+// Writes the headers in alphabetical order. If only is set, the other headers are skipped.
+func writeDebugHeaders(b *strings.Builder, prefix string, header http.Header, only []string) {
+	keys := make([]string, 0, len(header))
+
+	for key := range header {
+		if only == nil || slices.Contains(only, key) {
+			keys = append(keys, key)
+		}
+	}
+
+	slices.Sort(keys)
+
+	for _, key := range keys {
+		for _, value := range header[key] {
+			if slices.Contains(redactedHeaders, key) {
+				value = redacted
+			}
+
+			fmt.Fprintf(b, "%s%s: %s\n", prefix, key, value)
+		}
+	}
+}
+
+func writeDebugBody(b *strings.Builder, prefix string, body []byte) {
+	if len(body) == 0 {
+		return
+	}
+
+	fmt.Fprintf(b, "%s\n", strings.TrimSpace(prefix))
+
+	for line := range strings.SplitSeq(strings.TrimRight(redactBody(string(body)), "\n"), "\n") {
+		fmt.Fprintf(b, "%s%s\n", prefix, line)
+	}
+}
+
+// Replaces the values of tokens, secrets, and authorization codes in form and JSON bodies.
+func redactBody(body string) string {
+	body = redactForm(body)
+
+	return secretJSONFieldRe.ReplaceAllString(body, `"${1}"${2}"`+redacted+`"`)
+}
+
+func redactForm(form string) string {
+	return secretFormFieldRe.ReplaceAllString(form, "${1}${2}="+redacted)
+}
+
+func redactURL(u *url.URL) string {
+	c := *u
+
+	if c.User != nil {
+		c.User = url.User("REDACTED")
+	}
+
+	c.RawQuery = redactForm(c.RawQuery)
+
+	return c.String()
+}
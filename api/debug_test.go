@@ -0,0 +1,111 @@
+package api
+
+import (
+	"bytes"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"regexp"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_DebugTransport(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		assert.Equal(t, "client_secret=secret-client&grant_type=refresh_token&refresh_token=secret-refresh", string(body))
+
+		w.Header().Set("Content-Type", "application/json")
+		w.Header().Set("X-RateLimit-Remaining", "99")
+		w.Header().Set("X-Internal", "hidden")
+		_, _ = w.Write([]byte(`{"access_token": "secret-access","token_type":"Bearer","refresh_token":"secret-refresh"}`))
+	}))
+	defer server.Close()
+
+	w := new(bytes.Buffer)
+	client := &http.Client{Transport: NewDebugTransport(http.DefaultTransport, w, true)}
+
+	req, err := http.NewRequest(http.MethodPost, server.URL+"/oauth/token?token=secret-query&x=1", strings.NewReader(url.Values{
+		"grant_type":    {"refresh_token"},
+		"refresh_token": {"secret-refresh"},
+		"client_secret": {"secret-client"},
+	}.Encode()))
+	assert.NoError(t, err)
+	req.Header.Set("Authorization", "Bearer secret-access")
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+
+	res, err := client.Do(req)
+	assert.NoError(t, err)
+
+	body, _ := io.ReadAll(res.Body)
+	_ = res.Body.Close()
+	assert.Equal(t, `{"access_token": "secret-access","token_type":"Bearer","refresh_token":"secret-refresh"}`, string(body))
+
+	log := regexp.MustCompile(`\(\d+ms\)`).ReplaceAllString(w.String(), "(0ms)")
+	assert.Equal(t, `> POST `+server.URL+`/oauth/token?token=[REDACTED]&x=1
+> Authorization: [REDACTED]
+> Content-Type: application/x-www-form-urlencoded
+>
+> client_secret=[REDACTED]&grant_type=refresh_token&refresh_token=[REDACTED]
+< 200 OK (0ms)
+< Content-Type: application/json
+< X-Ratelimit-Remaining: 99
+<
+< {"access_token": "[REDACTED]","token_type":"Bearer","refresh_token":"[REDACTED]"}
+
+`, log)
+	assert.NotContains(t, w.String(), "secret-")
+}
+
+func Test_DebugTransport_DeviceCode(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"device_code":"secret-device","user_code":"secret-user","verification_uri":"https://dash.globalping.io/authorize/device","interval":5}`))
+	}))
+	defer server.Close()
+
+	w := new(bytes.Buffer)
+	client := &http.Client{Transport: NewDebugTransport(http.DefaultTransport, w, true)}
+
+	res, err := client.PostForm(server.URL+"/oauth/token", url.Values{
+		"grant_type":  {"urn:ietf:params:oauth:grant-type:device_code"},
+		"device_code": {"secret-device"},
+		"client_id":   {"client"},
+	})
+	assert.NoError(t, err)
+	_ = res.Body.Close()
+
+	log := regexp.MustCompile(`\(\d+ms\)`).ReplaceAllString(w.String(), "(0ms)")
+	assert.Equal(t, `> POST `+server.URL+`/oauth/token
+> Content-Type: application/x-www-form-urlencoded
+>
+> client_id=client&device_code=[REDACTED]&grant_type=urn%3Aietf%3Aparams%3Aoauth%3Agrant-type%3Adevice_code
+< 200 OK (0ms)
+< Content-Type: application/json
+<
+< {"device_code":"[REDACTED]","user_code":"[REDACTED]","verification_uri":"https://dash.globalping.io/authorize/device","interval":5}
+
+`, log)
+	assert.NotContains(t, w.String(), "secret-")
+}
+
+func Test_DebugTransport_NoBodies(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.WriteHeader(http.StatusTooManyRequests)
+		_, _ = w.Write([]byte(`{"error":{"type":"too_many_requests"}}`))
+	}))
+	defer server.Close()
+
+	w := new(bytes.Buffer)
+	client := &http.Client{Transport: NewDebugTransport(http.DefaultTransport, w, false)}
+
+	res, err := client.Get(server.URL + "/v1/limits")
+	assert.NoError(t, err)
+	_ = res.Body.Close()
+
+	log := regexp.MustCompile(`\(\d+ms\)`).ReplaceAllString(w.String(), "(0ms)")
+	assert.Equal(t, "> GET "+server.URL+"/v1/limits\n< 429 Too Many Requests (0ms)\n< Content-Type: text/plain; charset=utf-8\n\n", log)
+}
@@ -0,0 +1,209 @@
+package api
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/jsdelivr/globalping-cli/storage"
+)
+
+var (
+	ErrTypeDeviceAuthorizationFailed = "device_authorization_failed"
+	ErrTypeAuthorizationPending      = "authorization_pending"
+	ErrTypeSlowDown                  = "slow_down"
+	ErrTypeAccessDenied              = "access_denied"
+	ErrTypeExpiredToken              = "expired_token"
+)
+
+var (
+	deviceGrantType        = "urn:ietf:params:oauth:grant-type:device_code"
+	devicePollInterval     = 5 * time.Second // Used if the server doesn't return an interval
+	deviceSlowDownInterval = 5 * time.Second // Added to the interval on each slow_down response
+)
+
+// https://datatracker.ietf.org/doc/html/rfc8628#section-3.2
+type DeviceAuthorizationResponse struct {
+	DeviceCode              string `json:"device_code"`
+	UserCode                string `json:"user_code"`
+	VerificationURI         string `json:"verification_uri"`
+	VerificationURIComplete string `json:"verification_uri_complete"`
+	ExpiresIn               int64  `json:"expires_in"` // Seconds
+	Interval                int64  `json:"interval"`   // Seconds
+}
+
+func (c *client) AuthorizeDevice(ctx context.Context) (*DeviceAuthorizationResponse, error) {
+	q := url.Values{}
+	q.Set("client_id", c.authClientId)
+	q.Set("client_secret", c.authClientSecret)
+	q.Set("scope", "measurements")
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, c.authURL+"/oauth/device/code", strings.NewReader(q.Encode()))
+
+	if err != nil {
+		return nil, &AuthorizeError{
+			ErrorType:   ErrTypeDeviceAuthorizationFailed,
+			Description: err.Error(),
+		}
+	}
+
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	req.Header.Set("Content-Length", strconv.Itoa(len(q.Encode())))
+	resp, err := c.http.Do(req)
+
+	if err != nil {
+		return nil, &AuthorizeError{
+			ErrorType:   ErrTypeDeviceAuthorizationFailed,
+			Description: err.Error(),
+		}
+	}
+
+	defer func() {
+		_ = resp.Body.Close()
+	}()
+
+	if resp.StatusCode != http.StatusOK {
+		err := &AuthorizeError{
+			Code:        resp.StatusCode,
+			ErrorType:   ErrTypeDeviceAuthorizationFailed,
+			Description: resp.Status,
+		}
+		_ = json.NewDecoder(resp.Body).Decode(err)
+
+		return nil, err
+	}
+
+	res := &DeviceAuthorizationResponse{}
+	err = json.NewDecoder(resp.Body).Decode(res)
+
+	if err != nil {
+		return nil, &AuthorizeError{
+			ErrorType:   ErrTypeDeviceAuthorizationFailed,
+			Description: err.Error(),
+		}
+	}
+
+	return res, nil
+}
+
+func (c *client) AwaitDeviceToken(ctx context.Context, device *DeviceAuthorizationResponse) error {
+	if device.ExpiresIn > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, time.Duration(device.ExpiresIn)*time.Second)
+		defer cancel()
+	}
+
+	interval := devicePollInterval
+
+	if device.Interval > 0 {
+		interval = time.Duration(device.Interval) * time.Second
+	}
+
+	for {
+		timer := time.NewTimer(interval)
+
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+
+			if errors.Is(ctx.Err(), context.DeadlineExceeded) {
+				return &AuthorizeError{
+					ErrorType:   ErrTypeExpiredToken,
+					Description: "the device code has expired",
+				}
+			}
+
+			return ctx.Err()
+		case <-timer.C:
+		}
+
+		token, err := c.requestDeviceToken(ctx, device.DeviceCode)
+
+		if err == nil {
+			c.updateToken(token)
+
+			return nil
+		}
+
+		var authorizeErr *AuthorizeError
+
+		if !errors.As(err, &authorizeErr) {
+			return err
+		}
+
+		switch authorizeErr.ErrorType {
+		case ErrTypeAuthorizationPending:
+			continue
+		case ErrTypeSlowDown:
+			interval += deviceSlowDownInterval
+		default:
+			return err
+		}
+	}
+}
+
+func (c *client) requestDeviceToken(ctx context.Context, deviceCode string) (*storage.Token, error) {
+	q := url.Values{}
+	q.Set("client_id", c.authClientId)
+	q.Set("client_secret", c.authClientSecret)
+	q.Set("device_code", deviceCode)
+	q.Set("grant_type", deviceGrantType)
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, c.authURL+"/oauth/token", strings.NewReader(q.Encode()))
+
+	if err != nil {
+		return nil, &AuthorizeError{
+			ErrorType:   ErrTypeExchangeFailed,
+			Description: err.Error(),
+		}
+	}
+
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	req.Header.Set("Content-Length", strconv.Itoa(len(q.Encode())))
+	resp, err := c.http.Do(req)
+
+	if err != nil {
+		return nil, &AuthorizeError{
+			ErrorType:   ErrTypeExchangeFailed,
+			Description: err.Error(),
+		}
+	}
+
+	defer func() {
+		_ = resp.Body.Close()
+	}()
+
+	if resp.StatusCode != http.StatusOK {
+		err := &AuthorizeError{
+			Code:        resp.StatusCode,
+			ErrorType:   ErrTypeExchangeFailed,
+			Description: resp.Status,
+		}
+		_ = json.NewDecoder(resp.Body).Decode(err)
+
+		return nil, err
+	}
+
+	t := &storage.Token{}
+	err = json.NewDecoder(resp.Body).Decode(t)
+
+	if err != nil {
+		return nil, &AuthorizeError{
+			ErrorType:   ErrTypeExchangeFailed,
+			Description: err.Error(),
+		}
+	}
+
+	if t.TokenType == "" {
+		t.TokenType = "Bearer"
+	}
+
+	if t.ExpiresIn != 0 {
+		t.Expiry = c.utils.Now().Add(time.Duration(t.ExpiresIn) * time.Second)
+	}
+
+	return t, nil
+}
@@ -0,0 +1,139 @@
+package api
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	utilsMock "github.com/jsdelivr/globalping-cli/mocks/utils"
+	"github.com/jsdelivr/globalping-cli/storage"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/mock/gomock"
+)
+
+func Test_DeviceAuthorization(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	utilsMock := utilsMock.NewMockUtils(ctrl)
+	utilsMock.EXPECT().Now().Return(defaultCurrentTime).AnyTimes()
+
+	setDevicePollIntervals(t)
+
+	tokenResponses := []string{ErrTypeAuthorizationPending, ErrTypeSlowDown, ""}
+	tokenRequests := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		err := r.ParseForm()
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+
+		switch r.URL.Path {
+		case "/oauth/device/code":
+			assert.Equal(t, "<client_id>", r.Form.Get("client_id"))
+			assert.Equal(t, "measurements", r.Form.Get("scope"))
+
+			_ = json.NewEncoder(w).Encode(&DeviceAuthorizationResponse{
+				DeviceCode:      "d3vice",
+				UserCode:        "ABCD-EFGH",
+				VerificationURI: "https://dash.globalping.io/device",
+				ExpiresIn:       600,
+			})
+		case "/oauth/token":
+			assert.Equal(t, deviceGrantType, r.Form.Get("grant_type"))
+			assert.Equal(t, "d3vice", r.Form.Get("device_code"))
+
+			res := tokenResponses[tokenRequests]
+			tokenRequests++
+
+			if res != "" {
+				w.WriteHeader(http.StatusBadRequest)
+				_, _ = w.Write([]byte(`{"error":"` + res + `"}`))
+
+				return
+			}
+
+			_, _ = w.Write([]byte(`{"access_token":"tok3n","refresh_token":"refresh_tok3n","expires_in":3600}`))
+		default:
+			t.Fatalf("unexpected request to %s", r.URL.Path)
+		}
+	}))
+	defer server.Close()
+
+	_storage := createDefaultTestStorage(t, utilsMock)
+	client := NewClient(Config{
+		Utils:            utilsMock,
+		Storage:          _storage,
+		AuthClientID:     "<client_id>",
+		AuthClientSecret: "<client_secret>",
+		AuthURL:          server.URL,
+		DashboardURL:     server.URL,
+	})
+
+	device, err := client.AuthorizeDevice(t.Context())
+	assert.NoError(t, err)
+	assert.Equal(t, "ABCD-EFGH", device.UserCode)
+
+	err = client.AwaitDeviceToken(t.Context(), device)
+	assert.NoError(t, err)
+	assert.Equal(t, 3, tokenRequests)
+
+	assert.Equal(t, &storage.Token{
+		AccessToken:  "tok3n",
+		TokenType:    "Bearer",
+		RefreshToken: "refresh_tok3n",
+		ExpiresIn:    3600,
+		Expiry:       defaultCurrentTime.Add(time.Hour),
+	}, _storage.GetProfile().Token)
+}
+
+func Test_DeviceAuthorization_Denied(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	utilsMock := utilsMock.NewMockUtils(ctrl)
+	utilsMock.EXPECT().Now().Return(defaultCurrentTime).AnyTimes()
+
+	setDevicePollIntervals(t)
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusBadRequest)
+		_, _ = w.Write([]byte(`{"error":"access_denied","error_description":"the request was denied"}`))
+	}))
+	defer server.Close()
+
+	_storage := createDefaultTestStorage(t, utilsMock)
+	client := NewClient(Config{
+		Utils:   utilsMock,
+		Storage: _storage,
+		AuthURL: server.URL,
+		AuthToken: &storage.Token{
+			AccessToken: "oldToken",
+			Expiry:      defaultCurrentTime.Add(time.Hour),
+		},
+	})
+
+	err := client.AwaitDeviceToken(t.Context(), &DeviceAuthorizationResponse{DeviceCode: "d3vice", ExpiresIn: 600})
+	assert.Equal(t, &AuthorizeError{
+		Code:        http.StatusBadRequest,
+		ErrorType:   ErrTypeAccessDenied,
+		Description: "the request was denied",
+	}, err)
+	assert.Nil(t, _storage.GetProfile().Token)
+}
+
+func setDevicePollIntervals(t *testing.T) {
+	devicePollInterval = 10 * time.Millisecond
+	deviceSlowDownInterval = 10 * time.Millisecond
+
+	t.Cleanup(func() {
+		devicePollInterval = 5 * time.Second
+		deviceSlowDownInterval = 5 * time.Second
+	})
+}
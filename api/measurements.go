@@ -12,18 +12,14 @@ import (
 )
 
 var (
-	moreCreditsRequiredNoAuthErr = "You only have %s remaining, and %d were required. Try requesting fewer probes or wait %s for the rate limit to reset. You can get higher limits by creating an account. Sign up at https://dash.globalping.io?view=add-credits"
-	moreCreditsRequiredAuthErr   = "You only have %s remaining, and %d were required. Try requesting fewer probes or wait %s for the rate limit to reset. You can get higher limits by sponsoring us or hosting probes. Learn more at https://dash.globalping.io?view=add-credits"
-	noCreditsNoAuthErr           = "You have run out of credits for this session. You can wait %s for the rate limit to reset or get higher limits by creating an account. Sign up at https://dash.globalping.io?view=add-credits"
-	noCreditsAuthErr             = "You have run out of credits for this session. You can wait %s for the rate limit to reset or get higher limits by sponsoring us or hosting probes. Learn more at https://dash.globalping.io?view=add-credits"
+	moreCreditsRequiredNoAuthErr = "You only have %s remaining, and %d were required. Try requesting fewer probes or wait %s for the rate limit to reset. You can get higher limits by creating an account. Sign up at %s?view=add-credits"
+	moreCreditsRequiredAuthErr   = "You only have %s remaining, and %d were required. Try requesting fewer probes or wait %s for the rate limit to reset. You can get higher limits by sponsoring us or hosting probes. Learn more at %s?view=add-credits"
+	noCreditsNoAuthErr           = "You have run out of credits for this session. You can wait %s for the rate limit to reset or get higher limits by creating an account. Sign up at %s?view=add-credits"
+	noCreditsAuthErr             = "You have run out of credits for this session. You can wait %s for the rate limit to reset or get higher limits by sponsoring us or hosting probes. Learn more at %s?view=add-credits"
 	invalidRefreshTokenErr       = "You have been signed out by the API. Please try signing in again."
 	invalidTokenErr              = "Your access token has been rejected by the API. Try signing in with a new token."
 )
 
-var (
-	StatusUnauthorizedWithTokenRefreshed = 1000
-)
-
 func (c *client) CreateMeasurement(ctx context.Context, measurement *globalping.MeasurementCreate) (*globalping.MeasurementCreateResponse, error) {
 	token, err := c.getToken(ctx)
 
@@ -50,25 +46,41 @@ func (c *client) CreateMeasurement(ctx context.Context, measurement *globalping.
 	}
 
 	if apiErr.StatusCode == http.StatusUnauthorized || apiErr.StatusCode == http.StatusForbidden {
-		if token != nil {
-			if token.RefreshToken == "" {
-				apiErr.Message = invalidTokenErr
+		if token == nil {
+			return nil, apiErr
+		}
 
-				return nil, apiErr
-			}
+		if token.RefreshToken == "" {
+			apiErr.Message = invalidTokenErr
 
-			if c.tryToRefreshToken(ctx, token.RefreshToken) {
-				apiErr.StatusCode = StatusUnauthorizedWithTokenRefreshed
+			return nil, apiErr
+		}
 
-				return nil, apiErr
-			}
+		token = c.tryToRefreshToken(ctx, token.RefreshToken)
 
+		if token == nil {
 			apiErr.Message = invalidRefreshTokenErr
 
 			return nil, apiErr
 		}
 
-		return nil, apiErr
+		// Repeat the request with the new token
+		c.globalping.SetToken(token.AccessToken)
+		res, err = c.globalping.CreateMeasurement(ctx, measurement)
+
+		if err == nil {
+			return res, nil
+		}
+
+		if !errors.As(err, &apiErr) {
+			return nil, err
+		}
+
+		if apiErr.StatusCode == http.StatusUnauthorized || apiErr.StatusCode == http.StatusForbidden {
+			apiErr.Message = invalidTokenErr
+
+			return nil, apiErr
+		}
 	}
 
 	if apiErr.StatusCode == http.StatusUnprocessableEntity {
@@ -86,23 +98,23 @@ func (c *client) CreateMeasurement(ctx context.Context, measurement *globalping.
 
 		if token == nil {
 			if remaining > 0 {
-				apiErr.Message = fmt.Sprintf(moreCreditsRequiredNoAuthErr, utils.Pluralize(remaining, "credit"), requestCost, utils.FormatSeconds(rateLimitReset))
+				apiErr.Message = fmt.Sprintf(moreCreditsRequiredNoAuthErr, utils.Pluralize(remaining, "credit"), requestCost, utils.FormatSeconds(rateLimitReset), c.dashboardURL)
 
 				return nil, apiErr
 			}
 
-			apiErr.Message = fmt.Sprintf(noCreditsNoAuthErr, utils.FormatSeconds(rateLimitReset))
+			apiErr.Message = fmt.Sprintf(noCreditsNoAuthErr, utils.FormatSeconds(rateLimitReset), c.dashboardURL)
 
 			return nil, apiErr
 		}
 
 		if remaining > 0 {
-			apiErr.Message = fmt.Sprintf(moreCreditsRequiredAuthErr, utils.Pluralize(remaining, "credit"), requestCost, utils.FormatSeconds(rateLimitReset))
+			apiErr.Message = fmt.Sprintf(moreCreditsRequiredAuthErr, utils.Pluralize(remaining, "credit"), requestCost, utils.FormatSeconds(rateLimitReset), c.dashboardURL)
 
 			return nil, apiErr
 		}
 
-		apiErr.Message = fmt.Sprintf(noCreditsAuthErr, utils.FormatSeconds(rateLimitReset))
+		apiErr.Message = fmt.Sprintf(noCreditsAuthErr, utils.FormatSeconds(rateLimitReset), c.dashboardURL)
 
 		return nil, apiErr
 	}
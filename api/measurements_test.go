@@ -95,13 +95,19 @@ func Test_CreateMeasurement_Unauthorized_TokenRefreshed(t *testing.T) {
 
 	opts := &globalping.MeasurementCreate{}
 
+	expectedMeasurement := &globalping.MeasurementCreateResponse{ID: "abcd"}
+
 	globalpingMock := globalpingMock.NewMockClient(ctrl)
-	globalpingMock.EXPECT().CreateMeasurement(t.Context(), opts).Return(nil, &globalping.MeasurementError{
-		StatusCode: http.StatusUnauthorized,
-		Type:       "unauthorized",
-		Message:    "Unauthorized.",
-	}).Times(1)
-	globalpingMock.EXPECT().SetToken("access_token")
+	gomock.InOrder(
+		globalpingMock.EXPECT().SetToken("access_token"),
+		globalpingMock.EXPECT().CreateMeasurement(t.Context(), opts).Return(nil, &globalping.MeasurementError{
+			StatusCode: http.StatusUnauthorized,
+			Type:       "unauthorized",
+			Message:    "Unauthorized.",
+		}),
+		globalpingMock.EXPECT().SetToken("new_token"),
+		globalpingMock.EXPECT().CreateMeasurement(t.Context(), opts).Return(expectedMeasurement, nil),
+	)
 
 	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 		if r.URL.Path == "/oauth/token" {
@@ -151,11 +157,8 @@ func Test_CreateMeasurement_Unauthorized_TokenRefreshed(t *testing.T) {
 	})
 
 	res, err := client.CreateMeasurement(t.Context(), opts)
-	assert.Nil(t, res)
-	var measurementErr *globalping.MeasurementError
-	assert.ErrorAs(t, err, &measurementErr)
-	assert.Equal(t, StatusUnauthorizedWithTokenRefreshed, measurementErr.StatusCode)
-	assert.Equal(t, "Unauthorized.", measurementErr.Message)
+	assert.NoError(t, err)
+	assert.Equal(t, expectedMeasurement, res)
 
 	assert.Equal(t, &storage.Token{
 		AccessToken:  "new_token",
@@ -300,7 +303,7 @@ func Test_CreateMeasurement_MoreCreditsRequiredNoAuthError(t *testing.T) {
 		Globalping: globalpingMock,
 	})
 	_, err := client.CreateMeasurement(t.Context(), opts)
-	assert.EqualError(t, err, "rate_limit_exceeded: "+fmt.Sprintf(moreCreditsRequiredNoAuthErr, "2 credits", 2, "1 minute"))
+	assert.EqualError(t, err, "rate_limit_exceeded: "+fmt.Sprintf(moreCreditsRequiredNoAuthErr, "2 credits", 2, "1 minute", GlobalpingDashboardURL))
 
 	assert.Nil(t, _storage.GetProfile().Token)
 }
@@ -341,7 +344,7 @@ func Test_CreateMeasurement_MoreCreditsRequiredAuthError(t *testing.T) {
 	})
 
 	_, err := client.CreateMeasurement(t.Context(), opts)
-	assert.EqualError(t, err, "rate_limit_exceeded: "+fmt.Sprintf(moreCreditsRequiredAuthErr, "1 credit", 2, "40 seconds"))
+	assert.EqualError(t, err, "rate_limit_exceeded: "+fmt.Sprintf(moreCreditsRequiredAuthErr, "1 credit", 2, "40 seconds", GlobalpingDashboardURL))
 }
 
 func Test_CreateMeasurement_NoCreditsNoAuthError(t *testing.T) {
@@ -376,7 +379,7 @@ func Test_CreateMeasurement_NoCreditsNoAuthError(t *testing.T) {
 
 	_, err := client.CreateMeasurement(t.Context(), opts)
 
-	assert.EqualError(t, err, "rate_limit_exceeded: "+fmt.Sprintf(noCreditsNoAuthErr, "5 seconds"))
+	assert.EqualError(t, err, "rate_limit_exceeded: "+fmt.Sprintf(noCreditsNoAuthErr, "5 seconds", GlobalpingDashboardURL))
 }
 
 func Test_CreateMeasurement_NoCreditsAuthError(t *testing.T) {
@@ -404,9 +407,10 @@ func Test_CreateMeasurement_NoCreditsAuthError(t *testing.T) {
 	_storage := createDefaultTestStorage(t, utilsMock)
 
 	client := NewClient(Config{
-		Utils:      utilsMock,
-		Storage:    _storage,
-		Globalping: globalpingMock,
+		Utils:        utilsMock,
+		Storage:      _storage,
+		Globalping:   globalpingMock,
+		DashboardURL: "https://dash.staging.globalping.io",
 		AuthToken: &storage.Token{
 			AccessToken: "secret",
 			Expiry:      time.Now().Add(1 * time.Hour),
@@ -414,5 +418,156 @@ func Test_CreateMeasurement_NoCreditsAuthError(t *testing.T) {
 	})
 
 	_, err := client.CreateMeasurement(t.Context(), opts)
-	assert.EqualError(t, err, "rate_limit_exceeded: "+fmt.Sprintf(noCreditsAuthErr, "5 seconds"))
+	assert.EqualError(t, err, "rate_limit_exceeded: You have run out of credits for this session. You can wait 5 seconds for the rate limit to reset or get higher limits by sponsoring us or hosting probes. Learn more at https://dash.staging.globalping.io?view=add-credits")
+}
+
+func Test_CreateMeasurement_TokenNearExpiry_Refreshed(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	utilsMock := utilsMock.NewMockUtils(ctrl)
+	utilsMock.EXPECT().Now().Return(defaultCurrentTime).AnyTimes()
+
+	opts := &globalping.MeasurementCreate{}
+	expectedMeasurement := &globalping.MeasurementCreateResponse{ID: "abcd"}
+
+	globalpingMock := globalpingMock.NewMockClient(ctrl)
+	globalpingMock.EXPECT().SetToken("new_token").Times(1)
+	globalpingMock.EXPECT().CreateMeasurement(t.Context(), opts).Return(expectedMeasurement, nil).Times(1)
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/oauth/token" {
+			t.Fatalf("unexpected request to %s", r.URL.Path)
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"access_token":"new_token","token_type":"Bearer","refresh_token":"new_refresh_token","expires_in":3600}`))
+	}))
+	defer server.Close()
+
+	_storage := createDefaultTestStorage(t, utilsMock)
+	client := NewClient(Config{
+		Utils:      utilsMock,
+		Storage:    _storage,
+		Globalping: globalpingMock,
+		AuthURL:    server.URL,
+		AuthToken: &storage.Token{
+			AccessToken:  "access_token",
+			RefreshToken: "refresh_tok3n",
+			Expiry:       defaultCurrentTime.Add(30 * time.Second),
+		},
+	})
+
+	res, err := client.CreateMeasurement(t.Context(), opts)
+	assert.NoError(t, err)
+	assert.Equal(t, expectedMeasurement, res)
+	assert.Equal(t, "new_refresh_token", _storage.GetProfile().Token.RefreshToken)
+}
+
+func Test_CreateMeasurement_TokenRotatedByAnotherProcess(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	utilsMock := utilsMock.NewMockUtils(ctrl)
+	utilsMock.EXPECT().Now().Return(defaultCurrentTime).AnyTimes()
+
+	opts := &globalping.MeasurementCreate{}
+	expectedMeasurement := &globalping.MeasurementCreateResponse{ID: "abcd"}
+
+	globalpingMock := globalpingMock.NewMockClient(ctrl)
+	globalpingMock.EXPECT().SetToken("rotated_token").Times(1)
+	globalpingMock.EXPECT().CreateMeasurement(t.Context(), opts).Return(expectedMeasurement, nil).Times(1)
+
+	server := httptest.NewServer(http.HandlerFunc(func(_ http.ResponseWriter, r *http.Request) {
+		t.Fatalf("unexpected request to %s", r.URL.Path)
+	}))
+	defer server.Close()
+
+	_storage := createDefaultTestStorage(t, utilsMock)
+	_storage.GetProfile().Token = &storage.Token{
+		AccessToken:  "token",
+		RefreshToken: "refresh_tok3n",
+		Expiry:       defaultCurrentTime.Add(-1 * time.Hour),
+	}
+	client := NewClient(Config{
+		Utils:      utilsMock,
+		Storage:    _storage,
+		Globalping: globalpingMock,
+		AuthURL:    server.URL,
+	})
+
+	// Another process refreshes the token in the meantime
+	_storage.GetProfile().Token = &storage.Token{
+		AccessToken:  "rotated_token",
+		TokenType:    "Bearer",
+		RefreshToken: "rotated_refresh_token",
+		Expiry:       defaultCurrentTime.Add(time.Hour),
+	}
+	assert.NoError(t, _storage.SaveConfig())
+
+	res, err := client.CreateMeasurement(t.Context(), opts)
+	assert.NoError(t, err)
+	assert.Equal(t, expectedMeasurement, res)
+}
+
+func Test_CreateMeasurement_TokenRotatedByAnotherProcess_NearExpiry(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	utilsMock := utilsMock.NewMockUtils(ctrl)
+	utilsMock.EXPECT().Now().Return(defaultCurrentTime).AnyTimes()
+
+	opts := &globalping.MeasurementCreate{}
+	expectedMeasurement := &globalping.MeasurementCreateResponse{ID: "abcd"}
+
+	globalpingMock := globalpingMock.NewMockClient(ctrl)
+	globalpingMock.EXPECT().SetToken("new_token").Times(1)
+	globalpingMock.EXPECT().CreateMeasurement(t.Context(), opts).Return(expectedMeasurement, nil).Times(1)
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.NoError(t, r.ParseForm())
+
+		// The token used by this process was already rotated by the other one
+		if r.PostForm.Get("refresh_token") != "rotated_refresh_token" {
+			w.Header().Set("Content-Type", "application/json")
+			w.WriteHeader(http.StatusBadRequest)
+			_, _ = w.Write([]byte(`{"error":"invalid_grant","error_description":"invalid refresh token"}`))
+
+			return
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"access_token":"new_token","token_type":"Bearer","refresh_token":"new_refresh_token","expires_in":3600}`))
+	}))
+	defer server.Close()
+
+	_storage := createDefaultTestStorage(t, utilsMock)
+	_storage.GetProfile().Token = &storage.Token{
+		AccessToken:  "token",
+		RefreshToken: "refresh_tok3n",
+		Expiry:       defaultCurrentTime.Add(-1 * time.Hour),
+	}
+	client := NewClient(Config{
+		Utils:      utilsMock,
+		Storage:    _storage,
+		Globalping: globalpingMock,
+		AuthURL:    server.URL,
+	})
+
+	// Another process refreshes the token, which is close to expiry again by the time this one runs
+	_storage.GetProfile().Token = &storage.Token{
+		AccessToken:  "rotated_token",
+		TokenType:    "Bearer",
+		RefreshToken: "rotated_refresh_token",
+		Expiry:       defaultCurrentTime.Add(30 * time.Second),
+	}
+	assert.NoError(t, _storage.SaveConfig())
+
+	res, err := client.CreateMeasurement(t.Context(), opts)
+	assert.NoError(t, err)
+	assert.Equal(t, expectedMeasurement, res)
+
+	reloaded, err := _storage.ReloadConfig()
+	assert.NoError(t, err)
+	assert.Equal(t, "new_refresh_token", reloaded.Profiles[storage.DefaultProfileName].Token.RefreshToken)
 }
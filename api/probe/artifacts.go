@@ -0,0 +1,255 @@
+package probe
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+type ArtifactFormat string
+
+const (
+	ArtifactSystemd ArtifactFormat = "systemd"
+	ArtifactCompose ArtifactFormat = "compose"
+	ArtifactQuadlet ArtifactFormat = "quadlet"
+)
+
+var (
+	ErrInvalidArtifactFormat = errors.New("invalid output format")
+	ErrArtifactExists        = errors.New("file already exists")
+)
+
+// Returns a file which runs the container in the same way as RunContainer.
+func GenerateArtifact(format ArtifactFormat, c *Container) (string, error) {
+	switch format {
+	case ArtifactSystemd:
+		return generateSystemdUnit(c), nil
+	case ArtifactCompose:
+		return generateComposeFile(c), nil
+	case ArtifactQuadlet:
+		return generateQuadletUnit(c), nil
+	default:
+		return "", fmt.Errorf("%w: %s, must be one of systemd, compose, quadlet", ErrInvalidArtifactFormat, format)
+	}
+}
+
+// Returns the path where the artifact is installed. The compose file is written to the current directory.
+// Rootless units are installed for the current user, as the system directories require root.
+func ArtifactPath(format ArtifactFormat, c *Container) (string, error) {
+	if format == ArtifactCompose {
+		return "compose.yaml", nil
+	}
+
+	dir := "/etc"
+
+	if c.Rootless {
+		var err error
+		dir, err = os.UserConfigDir()
+
+		if err != nil {
+			return "", err
+		}
+	}
+
+	if format == ArtifactQuadlet {
+		return filepath.Join(dir, "containers", "systemd", c.Name+".container"), nil
+	}
+
+	if c.Rootless {
+		return filepath.Join(dir, "systemd", "user", c.Name+".service"), nil
+	}
+
+	return filepath.Join(dir, "systemd", "system", c.Name+".service"), nil
+}
+
+// Writes the artifact to its default location. An existing file is only replaced if overwrite is true.
+func (*probe) WriteArtifact(format ArtifactFormat, c *Container, content string, overwrite bool) (string, error) {
+	path, err := ArtifactPath(format, c)
+
+	if err != nil {
+		return "", err
+	}
+
+	err = os.MkdirAll(filepath.Dir(path), 0755)
+
+	if err != nil {
+		return "", fmt.Errorf("failed to create the directory: %w", err)
+	}
+
+	flags := os.O_WRONLY | os.O_CREATE | os.O_TRUNC
+
+	if !overwrite {
+		flags |= os.O_EXCL
+	}
+
+	// The file may contain the adoption token, so it's only readable by the owner
+	f, err := os.OpenFile(path, flags, 0600)
+
+	if err != nil {
+		if os.IsExist(err) {
+			return "", fmt.Errorf("%w: %s", ErrArtifactExists, path)
+		}
+
+		return "", fmt.Errorf("failed to write the file: %w", err)
+	}
+
+	_, err = f.WriteString(content)
+
+	if closeErr := f.Close(); err == nil {
+		err = closeErr
+	}
+
+	if err != nil {
+		return "", fmt.Errorf("failed to write the file: %w", err)
+	}
+
+	return path, nil
+}
+
+// Podman runs the container in the foreground of the service, while Docker needs the service of its daemon.
+func generateSystemdUnit(c *Container) string {
+	engine := "/usr/bin/docker"
+	args := "--rm --log-driver local --network host --name " + c.Name
+
+	var sb strings.Builder
+	sb.WriteString("[Unit]\nDescription=Globalping probe\n")
+
+	if c.Engine == ContainerEnginePodman {
+		engine = "/usr/bin/podman"
+		args = "--rm --cap-add=NET_RAW --network host --name " + c.Name
+		sb.WriteString("After=network-online.target\n")
+	} else {
+		sb.WriteString("After=docker.service network-online.target\nRequires=docker.service\n")
+	}
+
+	for _, env := range c.Env {
+		args += " --env " + quoteSystemdValue(env, true)
+	}
+
+	sb.WriteString(`Wants=network-online.target
+
+[Service]
+ExecStartPre=-` + engine + ` rm --force ` + c.Name + `
+ExecStart=` + engine + ` run ` + args + ` ` + c.Image + `
+ExecStop=` + engine + ` stop ` + c.Name + `
+Restart=always
+RestartSec=10
+
+[Install]
+WantedBy=` + systemdTarget(c) + `
+`)
+
+	return sb.String()
+}
+
+func generateComposeFile(c *Container) string {
+	var sb strings.Builder
+	sb.WriteString(`services:
+  ` + c.Name + `:
+    image: ` + c.Image + `
+    container_name: ` + c.Name + `
+    network_mode: host
+    restart: always
+    logging:
+      driver: local
+`)
+
+	if len(c.Env) > 0 {
+		sb.WriteString("    environment:\n")
+
+		for _, env := range c.Env {
+			key, value, _ := strings.Cut(env, "=")
+			sb.WriteString("      " + key + ": " + quoteComposeValue(value) + "\n")
+		}
+	}
+
+	return sb.String()
+}
+
+func generateQuadletUnit(c *Container) string {
+	var sb strings.Builder
+	sb.WriteString(`[Unit]
+Description=Globalping probe
+Wants=network-online.target
+After=network-online.target
+
+[Container]
+Image=` + qualifiedImage(c.Image) + `
+ContainerName=` + c.Name + `
+Network=host
+AddCapability=NET_RAW
+`)
+
+	for _, env := range c.Env {
+		sb.WriteString("Environment=" + quoteSystemdValue(env, false) + "\n")
+	}
+
+	sb.WriteString(`
+[Service]
+Restart=always
+
+[Install]
+WantedBy=multi-user.target default.target
+`)
+
+	return sb.String()
+}
+
+// Podman doesn't assume Docker Hub for short image names, so the registry is added if missing.
+func qualifiedImage(image string) string {
+	registry, _, found := strings.Cut(image, "/")
+
+	if found && (strings.ContainsAny(registry, ".:") || registry == "localhost") {
+		return image
+	}
+
+	return "docker.io/" + image
+}
+
+// User services can't depend on the system targets.
+func systemdTarget(c *Container) string {
+	if c.Rootless {
+		return "default.target"
+	}
+
+	return "multi-user.target"
+}
+
+// Returns the value as a double-quoted systemd word. Specifiers are escaped with %%,
+// and in command lines, variables are escaped with $$ so that systemd doesn't expand them.
+func quoteSystemdValue(value string, command bool) string {
+	var sb strings.Builder
+	sb.WriteByte('"')
+
+	for _, r := range value {
+		switch r {
+		case '\\', '"':
+			sb.WriteByte('\\')
+			sb.WriteRune(r)
+		case '%':
+			sb.WriteString("%%")
+		case '$':
+			if command {
+				sb.WriteString("$$")
+			} else {
+				sb.WriteRune(r)
+			}
+		default:
+			sb.WriteRune(r)
+		}
+	}
+
+	sb.WriteByte('"')
+
+	return sb.String()
+}
+
+// Returns the value as a single-quoted YAML scalar, with $ escaped as $$ so that Compose doesn't interpolate it.
+func quoteComposeValue(value string) string {
+	value = strings.ReplaceAll(value, "'", "''")
+	value = strings.ReplaceAll(value, "$", "$$")
+
+	return "'" + value + "'"
+}
@@ -0,0 +1,99 @@
+package probe
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_GenerateArtifact_Systemd_Quoting(t *testing.T) {
+	c := NewContainer(ContainerEngineDocker)
+	c.Env = []string{`GP_ADOPTION_TOKEN=a b%c$d"e\f`}
+
+	content, err := GenerateArtifact(ArtifactSystemd, c)
+	assert.NoError(t, err)
+	assert.Contains(t, content, `ExecStart=/usr/bin/docker run --rm --log-driver local --network host --name globalping-probe --env "GP_ADOPTION_TOKEN=a b%%c$$d\"e\\f" globalping/globalping-probe`+"\n")
+}
+
+func Test_GenerateArtifact_Systemd_Rootless(t *testing.T) {
+	c := NewContainer(ContainerEnginePodman)
+	c.Rootless = true
+
+	content, err := GenerateArtifact(ArtifactSystemd, c)
+	assert.NoError(t, err)
+	assert.Contains(t, content, "\nWantedBy=default.target\n")
+}
+
+func Test_GenerateArtifact_Quadlet_Quoting(t *testing.T) {
+	c := NewContainer(ContainerEnginePodman)
+	c.Env = []string{`GP_ADOPTION_TOKEN=a b%c$d"e\f`}
+
+	content, err := GenerateArtifact(ArtifactQuadlet, c)
+	assert.NoError(t, err)
+	assert.Contains(t, content, "\n"+`Environment="GP_ADOPTION_TOKEN=a b%%c$d\"e\\f"`+"\n")
+}
+
+func Test_GenerateArtifact_Compose_Quoting(t *testing.T) {
+	c := NewContainer(ContainerEngineDocker)
+	c.Env = []string{`GP_ADOPTION_TOKEN=a b%c$d'e"f\g: #h`}
+
+	content, err := GenerateArtifact(ArtifactCompose, c)
+	assert.NoError(t, err)
+	assert.Contains(t, content, "    environment:\n      GP_ADOPTION_TOKEN: "+`'a b%c$$d''e"f\g: #h'`+"\n")
+}
+
+func Test_ArtifactPath(t *testing.T) {
+	configDir := t.TempDir()
+	t.Setenv("XDG_CONFIG_HOME", configDir)
+	c := NewContainer(ContainerEnginePodman)
+
+	path, err := ArtifactPath(ArtifactSystemd, c)
+	assert.NoError(t, err)
+	assert.Equal(t, "/etc/systemd/system/globalping-probe.service", path)
+
+	path, err = ArtifactPath(ArtifactQuadlet, c)
+	assert.NoError(t, err)
+	assert.Equal(t, "/etc/containers/systemd/globalping-probe.container", path)
+
+	c.Rootless = true
+
+	path, err = ArtifactPath(ArtifactSystemd, c)
+	assert.NoError(t, err)
+	assert.Equal(t, filepath.Join(configDir, "systemd", "user", "globalping-probe.service"), path)
+
+	path, err = ArtifactPath(ArtifactQuadlet, c)
+	assert.NoError(t, err)
+	assert.Equal(t, filepath.Join(configDir, "containers", "systemd", "globalping-probe.container"), path)
+}
+
+func Test_WriteArtifact_Overwrite(t *testing.T) {
+	configDir := t.TempDir()
+	t.Setenv("XDG_CONFIG_HOME", configDir)
+	c := NewContainer(ContainerEnginePodman)
+	c.Rootless = true
+	p := NewProbe()
+
+	path, err := p.WriteArtifact(ArtifactQuadlet, c, "first", false)
+	assert.NoError(t, err)
+	assert.Equal(t, filepath.Join(configDir, "containers", "systemd", "globalping-probe.container"), path)
+
+	_, err = p.WriteArtifact(ArtifactQuadlet, c, "second", false)
+	assert.ErrorIs(t, err, ErrArtifactExists)
+
+	b, err := os.ReadFile(path)
+	assert.NoError(t, err)
+	assert.Equal(t, "first", string(b))
+
+	_, err = p.WriteArtifact(ArtifactQuadlet, c, "second", true)
+	assert.NoError(t, err)
+
+	b, err = os.ReadFile(path)
+	assert.NoError(t, err)
+	assert.Equal(t, "second", string(b))
+
+	info, err := os.Stat(path)
+	assert.NoError(t, err)
+	assert.Equal(t, os.FileMode(0600), info.Mode().Perm())
+}
@@ -1,7 +1,8 @@
 package probe
 
 import (
-	"os"
+	"errors"
+	"fmt"
 	"os/exec"
 	"strings"
 )
@@ -14,11 +15,26 @@ const (
 	ContainerEnginePodman  ContainerEngine = "Podman"
 )
 
+var (
+	ErrNoContainerEngine      = errors.New("no container engine detected")
+	ErrUnknownContainerEngine = errors.New("unknown container engine")
+)
+
+// Parses the name of a container engine, ignoring case.
+func ParseContainerEngine(name string) (ContainerEngine, error) {
+	switch strings.ToLower(name) {
+	case "docker":
+		return ContainerEngineDocker, nil
+	case "podman":
+		return ContainerEnginePodman, nil
+	default:
+		return ContainerEngineUnknown, fmt.Errorf("%w: %s, must be docker or podman", ErrUnknownContainerEngine, name)
+	}
+}
+
 func (*probe) DetectContainerEngine() (ContainerEngine, error) {
 	// check if docker is installed
-	dockerInfoCmd := exec.Command("docker", "info")
-	dockerInfoCmd.Stderr = os.Stderr
-	dockerInfoErr := dockerInfoCmd.Run()
+	dockerInfoErr := runCommand(exec.Command("docker", "info"))
 
 	if dockerInfoErr == nil {
 		// check if docker is aliased to podman
@@ -33,13 +49,11 @@ func (*probe) DetectContainerEngine() (ContainerEngine, error) {
 	}
 
 	// check if podman is installed
-	podmanInfoCmd := exec.Command("podman", "info")
-	podmanInfoCmd.Stderr = os.Stderr
-	podmanInfoErr := podmanInfoCmd.Run()
+	podmanInfoErr := runCommand(exec.Command("podman", "info"))
 
 	if podmanInfoErr == nil {
 		return ContainerEnginePodman, nil
 	}
 
-	return ContainerEngineUnknown, dockerInfoErr
+	return ContainerEngineUnknown, fmt.Errorf("%w: %w", ErrNoContainerEngine, dockerInfoErr)
 }
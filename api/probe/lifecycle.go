@@ -0,0 +1,265 @@
+package probe
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os/exec"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+)
+
+type ContainerStatus struct {
+	State     string // e.g. running, exited, restarting
+	StartedAt time.Time
+	Image     string // The image reference, e.g. globalping/globalping-probe
+	ImageID   string
+	Version   string   // The probe version from the image labels, empty if unknown
+	Env       []string // Environment variables set when the container was created, without the ones from the image
+}
+
+type containerInspect struct {
+	Image string `json:"Image"`
+	State struct {
+		Status    string    `json:"Status"`
+		StartedAt time.Time `json:"StartedAt"`
+	} `json:"State"`
+	Config struct {
+		Image  string            `json:"Image"`
+		Env    []string          `json:"Env"`
+		Labels map[string]string `json:"Labels"`
+	} `json:"Config"`
+}
+
+// Returns ErrContainerNotFound if the container is not installed.
+func (*probe) ContainerStatus(c *Container) (*ContainerStatus, error) {
+	cmd, err := c.command("inspect", c.Name)
+
+	if err != nil {
+		return nil, err
+	}
+
+	out, err := cmd.Output()
+
+	if err != nil {
+		var exitErr *exec.ExitError
+		output := ""
+
+		if errors.As(err, &exitErr) {
+			output = strings.TrimSpace(string(exitErr.Stderr))
+		}
+
+		// Other failures, e.g. a stopped daemon or a denied sudo, don't mean that the probe isn't installed
+		if isContainerNotFound(output) {
+			return nil, fmt.Errorf("%w: %s", ErrContainerNotFound, c.Name)
+		}
+
+		return nil, &CommandError{Command: formatCommand(cmd.Args), Output: output, Err: err}
+	}
+
+	status, err := parseContainerInspect(out)
+
+	if err != nil {
+		return nil, err
+	}
+
+	if status == nil {
+		return nil, fmt.Errorf("%w: %s", ErrContainerNotFound, c.Name)
+	}
+
+	if len(status.Env) == 0 {
+		return status, nil
+	}
+
+	// The container env also includes the variables defined by the image, which must not be carried over to a new image
+	cmd, _ = c.command("image", "inspect", status.ImageID, "--format", "{{json .Config.Env}}")
+	out, err = cmd.Output()
+
+	if err != nil {
+		return nil, &CommandError{Command: formatCommand(cmd.Args), Err: err}
+	}
+
+	imageEnv := []string{}
+	err = json.Unmarshal(out, &imageEnv)
+
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse the image details: %w", err)
+	}
+
+	status.Env = slices.DeleteFunc(status.Env, func(env string) bool {
+		return slices.Contains(imageEnv, env)
+	})
+
+	return status, nil
+}
+
+func (*probe) ContainerLogs(c *Container, tail int, follow bool, w io.Writer) error {
+	args := []string{"logs", "--tail", strconv.Itoa(tail)}
+
+	if follow {
+		args = append(args, "--follow")
+	}
+
+	cmd, err := c.command(append(args, c.Name)...)
+
+	if err != nil {
+		return err
+	}
+
+	// The probe logs to stderr, so both streams are shown
+	cmd.Stdout = w
+	cmd.Stderr = w
+	err = cmd.Run()
+
+	if err != nil {
+		return &CommandError{Command: formatCommand(cmd.Args), Err: err}
+	}
+
+	return nil
+}
+
+// Pulls the latest image and returns its ID.
+func (*probe) PullImage(c *Container) (string, error) {
+	cmd, err := c.command("pull", "--quiet", c.Image)
+
+	if err != nil {
+		return "", err
+	}
+
+	err = runCommand(cmd)
+
+	if err != nil {
+		return "", err
+	}
+
+	cmd, _ = c.command("image", "inspect", c.Image, "--format", "{{.Id}}")
+	out, err := cmd.Output()
+
+	if err != nil {
+		return "", &CommandError{Command: formatCommand(cmd.Args), Err: err}
+	}
+
+	return strings.TrimSpace(string(out)), nil
+}
+
+func (*probe) RestartContainer(c *Container) error {
+	cmd, err := c.command("restart", c.Name)
+
+	if err != nil {
+		return err
+	}
+
+	return runCommand(cmd)
+}
+
+// Stops and removes the container. The image is kept, so that the probe can be reinstalled quickly.
+func (*probe) RemoveContainer(c *Container) error {
+	cmd, err := c.command("rm", "--force", c.Name)
+
+	if err != nil {
+		return err
+	}
+
+	return runCommand(cmd)
+}
+
+// Recreates the container with the current settings, e.g. after a new image was pulled.
+// The old container is renamed and stopped rather than removed, and restored if the new container fails to start.
+func (p *probe) ReplaceContainer(c *Container) error {
+	previous := *c
+	previous.Name = c.Name + "-previous"
+
+	// Left over if a previous update was interrupted
+	_ = p.RemoveContainer(&previous)
+
+	cmd, err := c.command("stop", c.Name)
+
+	if err != nil {
+		return err
+	}
+
+	err = runCommand(cmd)
+
+	if err != nil {
+		return err
+	}
+
+	cmd, _ = c.command("rename", c.Name, previous.Name)
+	err = runCommand(cmd)
+
+	if err != nil {
+		return errors.Join(err, p.startContainer(c))
+	}
+
+	err = p.RunContainer(c)
+
+	if err != nil {
+		return errors.Join(err, p.restoreContainer(c, &previous))
+	}
+
+	return p.RemoveContainer(&previous)
+}
+
+// Removes the new container, if it was created, and renames and starts the previous one.
+func (p *probe) restoreContainer(c *Container, previous *Container) error {
+	_ = p.RemoveContainer(c)
+
+	cmd, _ := c.command("rename", previous.Name, c.Name)
+	err := runCommand(cmd)
+
+	if err != nil {
+		return fmt.Errorf("failed to restore the previous container %s: %w", previous.Name, err)
+	}
+
+	err = p.startContainer(c)
+
+	if err != nil {
+		return fmt.Errorf("failed to restore the previous container: %w", err)
+	}
+
+	return nil
+}
+
+func (*probe) startContainer(c *Container) error {
+	cmd, err := c.command("start", c.Name)
+
+	if err != nil {
+		return err
+	}
+
+	return runCommand(cmd)
+}
+
+// Returns true if the output of the container engine says that the container doesn't exist.
+func isContainerNotFound(output string) bool {
+	output = strings.ToLower(output)
+
+	return strings.Contains(output, "no such object") || strings.Contains(output, "no such container")
+}
+
+// Returns nil if the container doesn't exist.
+func parseContainerInspect(b []byte) (*ContainerStatus, error) {
+	res := []containerInspect{}
+	err := json.Unmarshal(b, &res)
+
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse the container details: %w", err)
+	}
+
+	// Podman keeps the container info for a while after it's deleted, with an empty status
+	if len(res) == 0 || res[0].State.Status == "" {
+		return nil, nil
+	}
+
+	return &ContainerStatus{
+		State:     res[0].State.Status,
+		StartedAt: res[0].State.StartedAt,
+		Image:     res[0].Config.Image,
+		ImageID:   res[0].Image,
+		Version:   res[0].Config.Labels["org.opencontainers.image.version"],
+		Env:       res[0].Config.Env,
+	}, nil
+}
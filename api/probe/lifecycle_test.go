@@ -0,0 +1,16 @@
+package probe
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_IsContainerNotFound(t *testing.T) {
+	assert.True(t, isContainerNotFound("Error: No such object: globalping-probe"))
+	assert.True(t, isContainerNotFound(`Error: no such container "globalping-probe"`))
+	assert.True(t, isContainerNotFound("Error response from daemon: No such container: globalping-probe"))
+	assert.False(t, isContainerNotFound("Cannot connect to the Docker daemon at unix:///var/run/docker.sock. Is the docker daemon running?"))
+	assert.False(t, isContainerNotFound("permission denied while trying to connect to the Docker daemon socket"))
+	assert.False(t, isContainerNotFound("sudo: a password is required"))
+}
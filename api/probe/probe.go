@@ -1,16 +1,73 @@
 package probe
 
 import (
-	"bytes"
+	"errors"
 	"fmt"
-	"os"
+	"io"
 	"os/exec"
+	"strings"
+)
+
+const (
+	DefaultContainerName = "globalping-probe"
+	DefaultImage         = "globalping/globalping-probe"
+
+	// The probe links itself to the account of the adoption token on the first start
+	AdoptionTokenEnv = "GP_ADOPTION_TOKEN"
+)
+
+var (
+	ErrContainerExists   = errors.New("container already exists")
+	ErrContainerNotFound = errors.New("container not found")
 )
 
 type Probe interface {
 	DetectContainerEngine() (ContainerEngine, error)
-	InspectContainer(containerEngine ContainerEngine) error
-	RunContainer(containerEngine ContainerEngine) error
+	InspectContainer(c *Container) error
+	RunContainer(c *Container) error
+	ContainerStatus(c *Container) (*ContainerStatus, error)
+	ContainerLogs(c *Container, tail int, follow bool, w io.Writer) error
+	PullImage(c *Container) (string, error)
+	RestartContainer(c *Container) error
+	RemoveContainer(c *Container) error
+	ReplaceContainer(c *Container) error
+	WriteArtifact(format ArtifactFormat, c *Container, content string, overwrite bool) (string, error)
+}
+
+// Describes how the probe container is run.
+type Container struct {
+	Engine   ContainerEngine
+	Name     string
+	Image    string
+	Rootless bool     // Run Podman without sudo
+	Env      []string // Environment variables in the KEY=VALUE format
+}
+
+func NewContainer(engine ContainerEngine) *Container {
+	return &Container{
+		Engine: engine,
+		Name:   DefaultContainerName,
+		Image:  DefaultImage,
+	}
+}
+
+// Returned when a container engine command fails, with the output of the command.
+type CommandError struct {
+	Command string // The command line, with the values of environment variables redacted
+	Output  string
+	Err     error
+}
+
+func (e *CommandError) Error() string {
+	if e.Output == "" {
+		return fmt.Sprintf("%s: %v", e.Command, e.Err)
+	}
+
+	return fmt.Sprintf("%s: %v: %s", e.Command, e.Err, e.Output)
+}
+
+func (e *CommandError) Unwrap() error {
+	return e.Err
 }
 
 type probe struct{}
@@ -19,101 +76,99 @@ func NewProbe() Probe {
 	return &probe{}
 }
 
-func (*probe) InspectContainer(containerEngine ContainerEngine) error {
-	switch containerEngine {
-	case ContainerEngineDocker:
-		err := inspectContainerDocker()
+// Returns ErrContainerExists if the container is already installed.
+func (*probe) InspectContainer(c *Container) error {
+	cmd, err := c.command("inspect", c.Name, "--format", "{{.State.Status}}")
 
-		if err != nil {
-			return err
-		}
-	case ContainerEnginePodman:
-		err := inspectContainerPodman()
-
-		if err != nil {
-			return err
-		}
-	default:
-		return fmt.Errorf("unknown container engine %s", containerEngine)
+	if err != nil {
+		return err
 	}
 
-	return nil
-}
+	out, err := cmd.Output()
 
-func (*probe) RunContainer(containerEngine ContainerEngine) error {
-	switch containerEngine {
-	case ContainerEngineDocker:
-		err := runContainerDocker()
+	if err != nil {
+		return nil
+	}
 
-		if err != nil {
-			return err
-		}
-	case ContainerEnginePodman:
-		err := runContainerPodman()
+	status := strings.TrimSpace(string(out))
 
-		if err != nil {
-			return err
-		}
-	default:
-		return fmt.Errorf("unknown container engine %s", containerEngine)
+	// False positive, as Podman keeps the container info for a while after it's deleted
+	if status == "" {
+		return nil
 	}
 
-	return nil
+	return fmt.Errorf("%w: %s (status: %s)", ErrContainerExists, c.Name, status)
 }
 
-func inspectContainerDocker() error {
-	cmd := exec.Command("docker", "inspect", "globalping-probe", "-f", "{{.State.Status}}")
-	containerStatus, err := cmd.Output()
+func (*probe) RunContainer(c *Container) error {
+	args := []string{"run", "-d", "--network", "host", "--restart", "always", "--name", c.Name}
 
-	if err == nil {
-		containerStatusStr := string(bytes.TrimSpace(containerStatus))
+	switch c.Engine {
+	case ContainerEngineDocker:
+		args = append(args, "--log-driver", "local")
+	case ContainerEnginePodman:
+		args = append(args, "--cap-add=NET_RAW")
+	}
 
-		return fmt.Errorf("the globalping-probe container is already installed on your system. Current status: %s", containerStatusStr)
+	for _, env := range c.Env {
+		args = append(args, "--env", env)
 	}
 
-	return nil
-}
+	cmd, err := c.command(append(args, c.Image)...)
 
-func inspectContainerPodman() error {
-	cmd := exec.Command("sudo", "podman", "inspect", "globalping-probe", "-f", "{{.State.Status}}")
-	containerStatus, err := cmd.Output()
+	if err != nil {
+		return err
+	}
 
-	if err == nil {
-		containerStatusStr := string(bytes.TrimSpace(containerStatus))
+	return runCommand(cmd)
+}
 
-		if containerStatusStr == "" {
-			// false positive as podmain keeps container info after deletion
-			return nil
+// Returns the command for the container engine. Podman is run with sudo, unless the container is rootless.
+func (c *Container) command(args ...string) (*exec.Cmd, error) {
+	switch c.Engine {
+	case ContainerEngineDocker:
+		return exec.Command("docker", args...), nil
+	case ContainerEnginePodman:
+		if c.Rootless {
+			return exec.Command("podman", args...), nil
 		}
 
-		return fmt.Errorf("the globalping-probe container is already installed on your system. Current status: %s", containerStatusStr)
+		return exec.Command("sudo", append([]string{"podman"}, args...)...), nil
+	default:
+		return nil, fmt.Errorf("%w: %s", ErrUnknownContainerEngine, c.Engine)
 	}
-
-	return nil
 }
 
-func runContainerDocker() error {
-	cmd := exec.Command("docker", "run", "-d", "--log-driver", "local", "--network", "host", "--restart", "always", "--name", "globalping-probe", "globalping/globalping-probe")
-	cmd.Stdout = os.Stdout
-	cmd.Stderr = os.Stderr
-	err := cmd.Run()
+// Runs the command, and returns a CommandError with its output if it fails.
+func runCommand(cmd *exec.Cmd) error {
+	out, err := cmd.CombinedOutput()
 
 	if err != nil {
-		return fmt.Errorf("failed to run container: %w", err)
+		return &CommandError{
+			Command: formatCommand(cmd.Args),
+			Output:  strings.TrimSpace(string(out)),
+			Err:     err,
+		}
 	}
 
 	return nil
 }
 
-func runContainerPodman() error {
-	cmd := exec.Command("sudo", "podman", "run", "--cap-add=NET_RAW", "-d", "--network", "host", "--restart=always", "--name", "globalping-probe", "globalping/globalping-probe")
-	cmd.Stdout = os.Stdout
-	cmd.Stderr = os.Stderr
-	err := cmd.Run()
+// Joins the command line for error messages. The values of the environment variables are redacted, as they may contain the adoption token.
+func formatCommand(args []string) string {
+	redacted := make([]string, len(args))
 
-	if err != nil {
-		return fmt.Errorf("failed to run container: %w", err)
+	for i, arg := range args {
+		redacted[i] = arg
+
+		if i > 0 && (args[i-1] == "--env" || args[i-1] == "-e") {
+			key, _, _ := strings.Cut(arg, "=")
+			redacted[i] = key + "=[REDACTED]"
+		} else if key, ok := strings.CutPrefix(arg, "--env="); ok {
+			key, _, _ = strings.Cut(key, "=")
+			redacted[i] = "--env=" + key + "=[REDACTED]"
+		}
 	}
 
-	return nil
+	return strings.Join(redacted, " ")
 }
@@ -0,0 +1,15 @@
+package probe
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_FormatCommand(t *testing.T) {
+	assert.Equal(t,
+		"sudo podman run -d --name globalping-probe --env GP_ADOPTION_TOKEN=[REDACTED] -e GP_HOST_HW=[REDACTED] --env=KEY=[REDACTED] globalping/globalping-probe",
+		formatCommand([]string{"sudo", "podman", "run", "-d", "--name", "globalping-probe", "--env", "GP_ADOPTION_TOKEN=secret", "-e", "GP_HOST_HW=true", "--env=KEY=value", "globalping/globalping-probe"}),
+	)
+	assert.Equal(t, "docker inspect globalping-probe", formatCommand([]string{"docker", "inspect", "globalping-probe"}))
+}
@@ -0,0 +1,11 @@
+package api
+
+import (
+	"context"
+
+	"github.com/jsdelivr/globalping-go"
+)
+
+func (c *client) Probes(ctx context.Context) (*globalping.ProbesResponse, error) {
+	return c.globalping.Probes(ctx)
+}
@@ -0,0 +1,194 @@
+package api
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+
+	"github.com/jsdelivr/globalping-cli/utils"
+	"github.com/jsdelivr/globalping-go"
+)
+
+var (
+	RecordingFileName = "interactions.jsonl"
+)
+
+const (
+	InteractionCreateMeasurement = "CreateMeasurement"
+	InteractionGetMeasurement    = "GetMeasurement"
+	InteractionAwaitMeasurement  = "AwaitMeasurement"
+	InteractionGetMeasurementRaw = "GetMeasurementRaw"
+	InteractionProbes            = "Probes"
+	InteractionLimits            = "Limits"
+)
+
+// A recorded API call.
+type Interaction struct {
+	Method   string          `json:"method"`
+	ID       string          `json:"id,omitempty"` // Measurement ID
+	Request  json.RawMessage `json:"request,omitempty"`
+	Response json.RawMessage `json:"response,omitempty"`
+	Error    *RecordedError  `json:"error,omitempty"`
+	Time     int64           `json:"time"`     // Milliseconds since the start of the recording
+	Duration int64           `json:"duration"` // Milliseconds
+}
+
+type RecordedError struct {
+	Type       string      `json:"type,omitempty"`
+	Message    string      `json:"message"`
+	StatusCode int         `json:"status_code,omitempty"`
+	Header     http.Header `json:"header,omitempty"`
+}
+
+func newRecordedError(err error) *RecordedError {
+	var measurementErr *globalping.MeasurementError
+
+	if errors.As(err, &measurementErr) {
+		return &RecordedError{
+			Type:       measurementErr.Type,
+			Message:    measurementErr.Message,
+			StatusCode: measurementErr.StatusCode,
+			Header:     measurementErr.Header,
+		}
+	}
+
+	return &RecordedError{Message: err.Error()}
+}
+
+func (e *RecordedError) toError() error {
+	if e.StatusCode == 0 {
+		return errors.New(e.Message)
+	}
+
+	return &globalping.MeasurementError{
+		Type:       e.Type,
+		Message:    e.Message,
+		StatusCode: e.StatusCode,
+		Header:     e.Header,
+	}
+}
+
+type recorder struct {
+	Client
+	utils   utils.Utils
+	f       *os.File
+	mu      sync.Mutex
+	startAt time.Time
+}
+
+// Returns a client which saves the measurement requests and responses of the wrapped client to dir.
+func NewRecorder(client Client, _utils utils.Utils, dir string) (Client, error) {
+	err := os.MkdirAll(dir, 0755)
+
+	if err != nil {
+		return nil, err
+	}
+
+	f, err := os.Create(filepath.Join(dir, RecordingFileName))
+
+	if err != nil {
+		return nil, err
+	}
+
+	return &recorder{
+		Client:  client,
+		utils:   _utils,
+		f:       f,
+		startAt: _utils.Now(),
+	}, nil
+}
+
+func (r *recorder) CreateMeasurement(ctx context.Context, measurement *globalping.MeasurementCreate) (*globalping.MeasurementCreateResponse, error) {
+	start := r.utils.Now()
+	res, err := r.Client.CreateMeasurement(ctx, measurement)
+	r.record(InteractionCreateMeasurement, "", measurement, res, err, start)
+
+	return res, err
+}
+
+func (r *recorder) GetMeasurement(ctx context.Context, id string) (*globalping.Measurement, error) {
+	start := r.utils.Now()
+	res, err := r.Client.GetMeasurement(ctx, id)
+	r.record(InteractionGetMeasurement, id, nil, res, err, start)
+
+	return res, err
+}
+
+func (r *recorder) AwaitMeasurement(ctx context.Context, id string) (*globalping.Measurement, error) {
+	start := r.utils.Now()
+	res, err := r.Client.AwaitMeasurement(ctx, id)
+	r.record(InteractionAwaitMeasurement, id, nil, res, err, start)
+
+	return res, err
+}
+
+func (r *recorder) GetMeasurementRaw(ctx context.Context, id string) ([]byte, error) {
+	start := r.utils.Now()
+	res, err := r.Client.GetMeasurementRaw(ctx, id)
+
+	if err == nil && json.Valid(res) {
+		r.record(InteractionGetMeasurementRaw, id, nil, json.RawMessage(res), nil, start)
+	} else {
+		r.record(InteractionGetMeasurementRaw, id, nil, nil, err, start)
+	}
+
+	return res, err
+}
+
+func (r *recorder) Probes(ctx context.Context) (*globalping.ProbesResponse, error) {
+	start := r.utils.Now()
+	res, err := r.Client.Probes(ctx)
+	r.record(InteractionProbes, "", nil, res, err, start)
+
+	return res, err
+}
+
+func (r *recorder) Limits(ctx context.Context) (*globalping.LimitsResponse, error) {
+	start := r.utils.Now()
+	res, err := r.Client.Limits(ctx)
+	r.record(InteractionLimits, "", nil, res, err, start)
+
+	return res, err
+}
+
+func (r *recorder) Close() {
+	r.Client.Close()
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	_ = r.f.Close()
+}
+
+func (r *recorder) record(method string, id string, request any, response any, err error, start time.Time) {
+	interaction := &Interaction{
+		Method:   method,
+		ID:       id,
+		Time:     start.Sub(r.startAt).Milliseconds(),
+		Duration: r.utils.Now().Sub(start).Milliseconds(),
+	}
+
+	if request != nil {
+		interaction.Request, _ = json.Marshal(request)
+	}
+
+	if err != nil {
+		interaction.Error = newRecordedError(err)
+	} else {
+		interaction.Response, _ = json.Marshal(response)
+	}
+
+	b, err := json.Marshal(interaction)
+
+	if err != nil {
+		return
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	_, _ = r.f.Write(append(b, '\n'))
+}
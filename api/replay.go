@@ -0,0 +1,282 @@
+package api
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+
+	"github.com/jsdelivr/globalping-go"
+)
+
+var (
+	ErrReplayNotAvailable = errors.New("not available in replay mode")
+	ErrReplayEnded        = errors.New("no more recorded measurements to replay")
+	ErrReplayNotRecorded  = errors.New("measurement was not recorded")
+)
+
+type replayClient struct {
+	mu           sync.Mutex
+	creates      []*Interaction
+	measurements map[string][]*Interaction // GetMeasurement responses by measurement ID
+	awaits       map[string]*Interaction
+	raws         map[string]*Interaction
+	probes       *Interaction
+	limits       *Interaction
+	last         map[string]*Interaction // The last returned GetMeasurement response by measurement ID
+}
+
+// Returns a client which serves the interactions recorded in dir, without making any network requests.
+// Measurements are created in the recorded order, and the responses are delayed by the recorded durations.
+func NewReplayClient(dir string) (Client, error) {
+	f, err := os.Open(filepath.Join(dir, RecordingFileName))
+
+	if err != nil {
+		return nil, err
+	}
+
+	defer func() {
+		_ = f.Close()
+	}()
+	c := &replayClient{
+		measurements: make(map[string][]*Interaction),
+		awaits:       make(map[string]*Interaction),
+		raws:         make(map[string]*Interaction),
+		last:         make(map[string]*Interaction),
+	}
+	scanner := bufio.NewScanner(f)
+	scanner.Buffer(make([]byte, 0, 64*1024), 64*1024*1024)
+	line := 0
+
+	for scanner.Scan() {
+		line++
+		interaction := &Interaction{}
+		err := json.Unmarshal(scanner.Bytes(), interaction)
+
+		if err != nil {
+			return nil, fmt.Errorf("invalid recording at line %d: %w", line, err)
+		}
+
+		switch interaction.Method {
+		case InteractionCreateMeasurement:
+			c.creates = append(c.creates, interaction)
+		case InteractionGetMeasurement:
+			c.measurements[interaction.ID] = append(c.measurements[interaction.ID], interaction)
+		case InteractionAwaitMeasurement:
+			c.awaits[interaction.ID] = interaction
+		case InteractionGetMeasurementRaw:
+			c.raws[interaction.ID] = interaction
+		case InteractionProbes:
+			c.probes = interaction
+		case InteractionLimits:
+			c.limits = interaction
+		}
+	}
+
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+
+	return c, nil
+}
+
+func (c *replayClient) CreateMeasurement(ctx context.Context, _ *globalping.MeasurementCreate) (*globalping.MeasurementCreateResponse, error) {
+	c.mu.Lock()
+
+	if len(c.creates) == 0 {
+		c.mu.Unlock()
+
+		return nil, ErrReplayEnded
+	}
+
+	interaction := c.creates[0]
+	c.creates = c.creates[1:]
+	c.mu.Unlock()
+
+	res := &globalping.MeasurementCreateResponse{}
+	err := replay(ctx, interaction, res)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return res, nil
+}
+
+// Returns the recorded responses for the measurement in order. Once they run out, the last one is repeated.
+func (c *replayClient) GetMeasurement(ctx context.Context, id string) (*globalping.Measurement, error) {
+	c.mu.Lock()
+	interaction := c.last[id]
+
+	if queue := c.measurements[id]; len(queue) > 0 {
+		interaction = queue[0]
+		c.measurements[id] = queue[1:]
+		c.last[id] = interaction
+	}
+
+	c.mu.Unlock()
+
+	if interaction == nil {
+		return nil, fmt.Errorf("%w: %s", ErrReplayNotRecorded, id)
+	}
+
+	res := &globalping.Measurement{}
+	err := replay(ctx, interaction, res)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return res, nil
+}
+
+func (c *replayClient) AwaitMeasurement(ctx context.Context, id string) (*globalping.Measurement, error) {
+	c.mu.Lock()
+	interaction := c.awaits[id]
+	c.mu.Unlock()
+
+	if interaction == nil {
+		return c.getFinalMeasurement(ctx, id)
+	}
+
+	res := &globalping.Measurement{}
+	err := replay(ctx, interaction, res)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return res, nil
+}
+
+func (c *replayClient) GetMeasurementRaw(ctx context.Context, id string) ([]byte, error) {
+	c.mu.Lock()
+	interaction := c.raws[id]
+	c.mu.Unlock()
+
+	if interaction == nil {
+		m, err := c.getFinalMeasurement(ctx, id)
+
+		if err != nil {
+			return nil, err
+		}
+
+		return json.Marshal(m)
+	}
+
+	res := json.RawMessage{}
+	err := replay(ctx, interaction, &res)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return res, nil
+}
+
+func (c *replayClient) Probes(ctx context.Context) (*globalping.ProbesResponse, error) {
+	if c.probes == nil {
+		return nil, fmt.Errorf("probes are %w", ErrReplayNotAvailable)
+	}
+
+	res := &globalping.ProbesResponse{}
+	err := replay(ctx, c.probes, res)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return res, nil
+}
+
+func (c *replayClient) Limits(ctx context.Context) (*globalping.LimitsResponse, error) {
+	if c.limits == nil {
+		return nil, fmt.Errorf("limits are %w", ErrReplayNotAvailable)
+	}
+
+	res := &globalping.LimitsResponse{}
+	err := replay(ctx, c.limits, res)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return res, nil
+}
+
+func (c *replayClient) Authorize(_ context.Context, _ func(error)) (*AuthorizeResponse, error) {
+	return nil, fmt.Errorf("authorization is %w", ErrReplayNotAvailable)
+}
+
+func (c *replayClient) AuthorizeDevice(_ context.Context) (*DeviceAuthorizationResponse, error) {
+	return nil, fmt.Errorf("authorization is %w", ErrReplayNotAvailable)
+}
+
+func (c *replayClient) AwaitDeviceToken(_ context.Context, _ *DeviceAuthorizationResponse) error {
+	return fmt.Errorf("authorization is %w", ErrReplayNotAvailable)
+}
+
+func (c *replayClient) TokenIntrospection(_ context.Context, _ string) (*IntrospectionResponse, error) {
+	return nil, fmt.Errorf("token introspection is %w", ErrReplayNotAvailable)
+}
+
+func (c *replayClient) Logout(_ context.Context) error {
+	return fmt.Errorf("logout is %w", ErrReplayNotAvailable)
+}
+
+func (c *replayClient) RevokeToken(_ context.Context, _ string) error {
+	return fmt.Errorf("token revocation is %w", ErrReplayNotAvailable)
+}
+
+func (c *replayClient) Close() {}
+
+// Returns the last recorded state of the measurement.
+func (c *replayClient) getFinalMeasurement(ctx context.Context, id string) (*globalping.Measurement, error) {
+	c.mu.Lock()
+	interaction := c.last[id]
+
+	if queue := c.measurements[id]; len(queue) > 0 {
+		interaction = queue[len(queue)-1]
+	}
+
+	c.mu.Unlock()
+
+	if interaction == nil {
+		return nil, fmt.Errorf("%w: %s", ErrReplayNotRecorded, id)
+	}
+
+	res := &globalping.Measurement{}
+	err := replay(ctx, interaction, res)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return res, nil
+}
+
+// Waits for the recorded duration and decodes the recorded response into v.
+func replay(ctx context.Context, interaction *Interaction, v any) error {
+	if interaction.Duration > 0 {
+		timer := time.NewTimer(time.Duration(interaction.Duration) * time.Millisecond)
+
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+
+			return ctx.Err()
+		case <-timer.C:
+		}
+	}
+
+	if interaction.Error != nil {
+		return interaction.Error.toError()
+	}
+
+	return json.Unmarshal(interaction.Response, v)
+}
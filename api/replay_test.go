@@ -0,0 +1,115 @@
+package api
+
+import (
+	"context"
+	"net/http"
+	"os"
+	"path/filepath"
+	"testing"
+
+	utilsMock "github.com/jsdelivr/globalping-cli/mocks/utils"
+	"github.com/jsdelivr/globalping-go"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/mock/gomock"
+)
+
+type fakeClient struct {
+	Client
+	measurements []*globalping.Measurement
+	closed       bool
+}
+
+func (c *fakeClient) CreateMeasurement(_ context.Context, measurement *globalping.MeasurementCreate) (*globalping.MeasurementCreateResponse, error) {
+	if measurement.Target == "invalid" {
+		return nil, &globalping.MeasurementError{
+			Type:       "validation_error",
+			Message:    "invalid target",
+			StatusCode: http.StatusBadRequest,
+			Header:     http.Header{"X-Request-Cost": []string{"1"}},
+		}
+	}
+
+	return &globalping.MeasurementCreateResponse{ID: "abcd", ProbesCount: 1}, nil
+}
+
+func (c *fakeClient) GetMeasurement(_ context.Context, _ string) (*globalping.Measurement, error) {
+	m := c.measurements[0]
+
+	if len(c.measurements) > 1 {
+		c.measurements = c.measurements[1:]
+	}
+
+	return m, nil
+}
+
+func (c *fakeClient) Close() {
+	c.closed = true
+}
+
+func Test_Record_Replay(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	utilsMock := utilsMock.NewMockUtils(ctrl)
+	utilsMock.EXPECT().Now().Return(defaultCurrentTime).AnyTimes()
+
+	inProgress := &globalping.Measurement{ID: "abcd", Status: globalping.MeasurementStatusInProgress, ProbesCount: 1}
+	finished := &globalping.Measurement{ID: "abcd", Status: globalping.MeasurementStatusFinished, ProbesCount: 1}
+	fake := &fakeClient{measurements: []*globalping.Measurement{inProgress, finished}}
+	dir := filepath.Join(t.TempDir(), "recording")
+
+	recorder, err := NewRecorder(fake, utilsMock, dir)
+	assert.NoError(t, err)
+
+	_, err = recorder.CreateMeasurement(t.Context(), &globalping.MeasurementCreate{Type: "ping", Target: "invalid"})
+	assert.Error(t, err)
+	res, err := recorder.CreateMeasurement(t.Context(), &globalping.MeasurementCreate{Type: "ping", Target: "jsdelivr.com"})
+	assert.NoError(t, err)
+	assert.Equal(t, "abcd", res.ID)
+	_, _ = recorder.GetMeasurement(t.Context(), "abcd")
+	_, _ = recorder.GetMeasurement(t.Context(), "abcd")
+	recorder.Close()
+	assert.True(t, fake.closed)
+
+	b, err := os.ReadFile(filepath.Join(dir, RecordingFileName))
+	assert.NoError(t, err)
+	assert.Contains(t, string(b), `"error":{"type":"validation_error","message":"invalid target","status_code":400,"header":{"X-Request-Cost":["1"]}},"time":0,"duration":0}`)
+
+	replay, err := NewReplayClient(dir)
+	assert.NoError(t, err)
+
+	_, err = replay.CreateMeasurement(t.Context(), &globalping.MeasurementCreate{})
+	assert.Equal(t, &globalping.MeasurementError{
+		Type:       "validation_error",
+		Message:    "invalid target",
+		StatusCode: http.StatusBadRequest,
+		Header:     http.Header{"X-Request-Cost": []string{"1"}},
+	}, err)
+
+	res, err = replay.CreateMeasurement(t.Context(), &globalping.MeasurementCreate{})
+	assert.NoError(t, err)
+	assert.Equal(t, &globalping.MeasurementCreateResponse{ID: "abcd", ProbesCount: 1}, res)
+
+	_, err = replay.CreateMeasurement(t.Context(), &globalping.MeasurementCreate{})
+	assert.ErrorIs(t, err, ErrReplayEnded)
+
+	m, err := replay.AwaitMeasurement(t.Context(), "abcd")
+	assert.NoError(t, err)
+	assert.Equal(t, finished.Status, m.Status)
+
+	m, err = replay.GetMeasurement(t.Context(), "abcd")
+	assert.NoError(t, err)
+	assert.Equal(t, inProgress.Status, m.Status)
+	m, err = replay.GetMeasurement(t.Context(), "abcd")
+	assert.NoError(t, err)
+	assert.Equal(t, finished.Status, m.Status)
+	m, err = replay.GetMeasurement(t.Context(), "abcd")
+	assert.NoError(t, err)
+	assert.Equal(t, finished.Status, m.Status)
+
+	_, err = replay.GetMeasurement(t.Context(), "efgh")
+	assert.ErrorIs(t, err, ErrReplayNotRecorded)
+
+	_, err = replay.Limits(t.Context())
+	assert.ErrorIs(t, err, ErrReplayNotAvailable)
+}
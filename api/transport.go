@@ -0,0 +1,90 @@
+package api
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"os"
+	"slices"
+)
+
+var (
+	ErrInvalidProxy      = errors.New("invalid proxy URL")
+	ErrInvalidCACert     = errors.New("invalid CA certificate")
+	ErrInvalidClientCert = errors.New("invalid client certificate")
+)
+
+type TransportConfig struct {
+	Proxy      string // HTTP, HTTPS, or SOCKS5 proxy URL. If empty, the HTTP_PROXY, HTTPS_PROXY, and NO_PROXY environment variables are used.
+	CACert     string // Path to a PEM bundle with CA certificates trusted in addition to the system ones
+	ClientCert string // Path to a PEM client certificate for mutual TLS
+	ClientKey  string // Path to the PEM key of the client certificate. If empty, the key is read from ClientCert.
+}
+
+// Returns an HTTP transport with the proxy and TLS settings applied.
+func NewTransport(config TransportConfig) (*http.Transport, error) {
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+
+	if config.Proxy != "" {
+		proxyURL, err := url.Parse(config.Proxy)
+
+		if err != nil || !slices.Contains([]string{"http", "https", "socks5", "socks5h"}, proxyURL.Scheme) || proxyURL.Host == "" {
+			return nil, fmt.Errorf("%w: %s", ErrInvalidProxy, config.Proxy)
+		}
+
+		transport.Proxy = http.ProxyURL(proxyURL)
+	}
+
+	if config.ClientKey != "" && config.ClientCert == "" {
+		return nil, fmt.Errorf("%w: a client key requires a client certificate", ErrInvalidClientCert)
+	}
+
+	if config.CACert == "" && config.ClientCert == "" {
+		return transport, nil
+	}
+
+	tlsConfig := &tls.Config{MinVersion: tls.VersionTLS12}
+
+	if config.CACert != "" {
+		b, err := os.ReadFile(config.CACert)
+
+		if err != nil {
+			return nil, fmt.Errorf("%w: %w", ErrInvalidCACert, err)
+		}
+
+		pool, err := x509.SystemCertPool()
+
+		if err != nil {
+			pool = x509.NewCertPool()
+		}
+
+		if !pool.AppendCertsFromPEM(b) {
+			return nil, fmt.Errorf("%w: no certificates found in %s", ErrInvalidCACert, config.CACert)
+		}
+
+		tlsConfig.RootCAs = pool
+	}
+
+	if config.ClientCert != "" {
+		keyFile := config.ClientKey
+
+		if keyFile == "" {
+			keyFile = config.ClientCert
+		}
+
+		cert, err := tls.LoadX509KeyPair(config.ClientCert, keyFile)
+
+		if err != nil {
+			return nil, fmt.Errorf("%w: %w", ErrInvalidClientCert, err)
+		}
+
+		tlsConfig.Certificates = []tls.Certificate{cert}
+	}
+
+	transport.TLSClientConfig = tlsConfig
+
+	return transport, nil
+}
@@ -0,0 +1,72 @@
+package api
+
+import (
+	"encoding/pem"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_NewTransport_Proxy(t *testing.T) {
+	transport, err := NewTransport(TransportConfig{Proxy: "socks5://localhost:1080"})
+	assert.NoError(t, err)
+
+	req, _ := http.NewRequest(http.MethodGet, "https://api.globalping.io/v1/limits", nil)
+	proxyURL, err := transport.Proxy(req)
+	assert.NoError(t, err)
+	assert.Equal(t, "socks5://localhost:1080", proxyURL.String())
+
+	_, err = NewTransport(TransportConfig{Proxy: "localhost:1080"})
+	assert.ErrorIs(t, err, ErrInvalidProxy)
+
+	_, err = NewTransport(TransportConfig{Proxy: "ftp://localhost:1080"})
+	assert.ErrorIs(t, err, ErrInvalidProxy)
+}
+
+func Test_NewTransport_CACert(t *testing.T) {
+	server := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+
+	// The server certificate is not trusted by default
+	transport, err := NewTransport(TransportConfig{})
+	assert.NoError(t, err)
+
+	_, err = (&http.Client{Transport: transport}).Get(server.URL)
+	assert.Error(t, err)
+
+	caPath := filepath.Join(t.TempDir(), "ca.pem")
+	err = os.WriteFile(caPath, pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: server.Certificate().Raw}), 0644)
+	assert.NoError(t, err)
+
+	transport, err = NewTransport(TransportConfig{CACert: caPath})
+	assert.NoError(t, err)
+
+	res, err := (&http.Client{Transport: transport}).Get(server.URL)
+	assert.NoError(t, err)
+	assert.Equal(t, http.StatusOK, res.StatusCode)
+	_ = res.Body.Close()
+}
+
+func Test_NewTransport_Invalid_Certs(t *testing.T) {
+	invalidPath := filepath.Join(t.TempDir(), "invalid.pem")
+	err := os.WriteFile(invalidPath, []byte("not a certificate"), 0644)
+	assert.NoError(t, err)
+
+	_, err = NewTransport(TransportConfig{CACert: invalidPath})
+	assert.ErrorIs(t, err, ErrInvalidCACert)
+
+	_, err = NewTransport(TransportConfig{CACert: filepath.Join(t.TempDir(), "missing.pem")})
+	assert.ErrorIs(t, err, ErrInvalidCACert)
+
+	_, err = NewTransport(TransportConfig{ClientCert: invalidPath})
+	assert.ErrorIs(t, err, ErrInvalidClientCert)
+
+	_, err = NewTransport(TransportConfig{ClientKey: invalidPath})
+	assert.ErrorIs(t, err, ErrInvalidClientCert)
+}
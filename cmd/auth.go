@@ -3,12 +3,17 @@ package cmd
 import (
 	"context"
 	"errors"
+	"fmt"
 	"math"
 	"os/signal"
+	"slices"
+	"strings"
 	"syscall"
+	"time"
 
 	"github.com/jsdelivr/globalping-cli/api"
 	"github.com/jsdelivr/globalping-cli/storage"
+	"github.com/jsdelivr/globalping-cli/utils"
 	"github.com/spf13/cobra"
 )
 
@@ -23,19 +28,31 @@ func (r *Root) initAuth() {
 		RunE:  r.RunAuthLogin,
 		Use:   "login",
 		Short: "Log in to your Globalping account",
-		Long:  `Log in to your Globalping account for higher measurements limits.`,
+		Long: `Log in to your Globalping account for higher measurements limits.
+The token is stored in the active profile; use the global --profile flag to log in to a different account.`,
 	}
 
 	loginFlags := loginCmd.Flags()
 	loginFlags.Bool("with-token", false, "authenticate with a token read from stdin instead of the default browser-based flow")
+	loginFlags.Bool("device", false, "authenticate by entering a code on a different device instead of the default browser-based flow; use this on hosts without a browser")
+	loginCmd.MarkFlagsMutuallyExclusive("with-token", "device")
 
 	statusCmd := &cobra.Command{
 		RunE:  r.RunAuthStatus,
 		Use:   "status",
 		Short: "Check the current authentication status",
-		Long:  `Check the current authentication status.`,
+		Long: `Check the current authentication status and show which token is used: the account, the OAuth client, the scopes, when the token expires, and whether it comes from the GLOBALPING_TOKEN environment variable or a stored profile.
+
+Examples:
+  # Show the details of the token in use.
+  auth status
+
+  # Output the status in JSON format.
+  auth status --json`,
 	}
 
+	statusCmd.Flags().BoolP("json", "J", false, "output the status in JSON format (default false)")
+
 	logoutCmd := &cobra.Command{
 		RunE:  r.RunAuthLogout,
 		Use:   "logout",
@@ -43,13 +60,78 @@ func (r *Root) initAuth() {
 		Long:  `Log out from your Globalping account.`,
 	}
 
+	profilesCmd := &cobra.Command{
+		Use:   "profiles",
+		Short: "Manage authentication profiles",
+		Long: `Manage authentication profiles. Each profile stores its own token, so you can stay logged in to multiple accounts and switch between them.
+Use the global --profile flag or the GLOBALPING_PROFILE environment variable to use a profile for a single command.
+
+Examples:
+  # Log in to a second account and store its token in the "work" profile.
+  auth login --profile work
+
+  # Run a measurement using the "work" profile.
+  ping google.com --profile work
+
+  # Make "work" the default profile.
+  auth profiles use work`,
+	}
+
+	profilesListCmd := &cobra.Command{
+		RunE:  r.RunAuthProfilesList,
+		Use:   "list",
+		Short: "List the stored profiles",
+		Long:  `List the stored profiles. The active profile is marked with an asterisk.`,
+		Args:  cobra.NoArgs,
+	}
+
+	profilesUseCmd := &cobra.Command{
+		RunE:  r.RunAuthProfilesUse,
+		Use:   "use [profile]",
+		Short: "Set the default profile",
+		Long:  `Set the profile used by default when the --profile flag is not provided.`,
+		Args:  cobra.ExactArgs(1),
+	}
+
+	profilesDeleteCmd := &cobra.Command{
+		RunE:  r.RunAuthProfilesDelete,
+		Use:   "delete [profile]",
+		Short: "Delete a profile and revoke its token",
+		Long:  `Delete a profile and revoke its token.`,
+		Args:  cobra.ExactArgs(1),
+	}
+
+	profilesCmd.AddCommand(profilesListCmd)
+	profilesCmd.AddCommand(profilesUseCmd)
+	profilesCmd.AddCommand(profilesDeleteCmd)
+
 	authCmd.AddCommand(loginCmd)
 	authCmd.AddCommand(statusCmd)
 	authCmd.AddCommand(logoutCmd)
+	authCmd.AddCommand(profilesCmd)
 
 	r.Cmd.AddCommand(authCmd)
 }
 
+type authStatusOutput struct {
+	LoggedIn     bool       `json:"logged_in"`
+	Username     string     `json:"username,omitempty"`
+	ClientID     string     `json:"client_id,omitempty"`
+	Scopes       []string   `json:"scopes,omitempty"`
+	ExpiresAt    *time.Time `json:"expires_at,omitempty"`
+	RefreshToken bool       `json:"refresh_token"`
+	Source       string     `json:"source,omitempty"`  // "env" or "profile", empty if there is no token
+	Profile      string     `json:"profile,omitempty"` // Set if the token is stored in a profile
+}
+
+func (s *authStatusOutput) describeSource() string {
+	if s.Source == "env" {
+		return "GLOBALPING_TOKEN environment variable"
+	}
+
+	return fmt.Sprintf("stored in the %q profile", s.Profile)
+}
+
 func (r *Root) RunAuthLogin(cmd *cobra.Command, _ []string) error {
 	ctx := cmd.Context()
 
@@ -71,6 +153,22 @@ func (r *Root) RunAuthLogin(cmd *cobra.Command, _ []string) error {
 		return nil
 	}
 
+	if cmd.Flags().Changed("device") {
+		err := r.loginWithDevice(ctx)
+
+		if err != nil {
+			r.Cmd.SilenceUsage = true
+
+			return err
+		}
+
+		if oldToken != nil {
+			_ = r.client.RevokeToken(ctx, oldToken.RefreshToken)
+		}
+
+		return nil
+	}
+
 	signal.Notify(r.cancel, syscall.SIGINT, syscall.SIGTERM)
 
 	res, err := r.client.Authorize(ctx, func(e error) {
@@ -99,7 +197,7 @@ func (r *Root) RunAuthLogin(cmd *cobra.Command, _ []string) error {
 	r.printer.Println("Please visit the following URL to authenticate:")
 	r.printer.Println(res.AuthorizeURL)
 	_ = r.utils.OpenBrowser(res.AuthorizeURL)
-	r.printer.Println("\nCan't use the browser-based flow? Use \"globalping auth login --with-token\" to read a token from stdin instead.")
+	r.printer.Println("\nCan't use the browser-based flow? Use \"globalping auth login --device\" to log in from a different device, or \"globalping auth login --with-token\" to read a token from stdin instead.")
 	<-r.cancel
 
 	return err
@@ -107,25 +205,68 @@ func (r *Root) RunAuthLogin(cmd *cobra.Command, _ []string) error {
 
 func (r *Root) RunAuthStatus(cmd *cobra.Command, _ []string) error {
 	ctx := cmd.Context()
-
+	toJSON, _ := cmd.Flags().GetBool("json")
+	status := r.authStatus()
 	res, err := r.client.TokenIntrospection(ctx, "")
 
 	if err != nil {
 		var authorizeErr *api.AuthorizeError
 
-		if errors.As(err, &authorizeErr) && authorizeErr.ErrorType == api.ErrTypeNotAuthorized {
-			r.printer.Println("Not logged in.")
+		if !errors.As(err, &authorizeErr) || authorizeErr.ErrorType != api.ErrTypeNotAuthorized {
+			return err
+		}
+	} else if res.Active {
+		status.LoggedIn = true
+		status.Username = res.Username
+		status.ClientID = res.ClientID
+		status.Scopes = strings.Fields(res.Scope)
+
+		if res.Exp > 0 {
+			expiresAt := time.Unix(res.Exp, 0).UTC()
+			status.ExpiresAt = &expiresAt
+		}
+	}
+
+	if toJSON {
+		return r.printJSON(status)
+	}
+
+	if !status.LoggedIn {
+		r.printer.Println("Not logged in.")
 
-			return nil
+		// A rejected token is still shown, so that it's clear which one to replace
+		if status.Source != "" {
+			r.printer.Printf("\nToken source:  %s\n", status.describeSource())
 		}
 
-		return err
+		return nil
 	}
 
-	if res.Active {
-		r.printer.Printf("Logged in as %s.\n", res.Username)
+	r.printer.Printf("Logged in as %s.\n\n", status.Username)
+
+	if status.Source != "" {
+		r.printer.Printf("Token source:  %s\n", status.describeSource())
+	}
+
+	if status.ClientID != "" {
+		r.printer.Printf("Client:        %s\n", status.ClientID)
+	}
+
+	if len(status.Scopes) > 0 {
+		r.printer.Printf("Scopes:        %s\n", strings.Join(status.Scopes, ", "))
+	}
+
+	if status.ExpiresAt != nil {
+		expiresIn := int64(status.ExpiresAt.Sub(r.utils.Now()).Seconds())
+		r.printer.Printf("Expires:       %s (in %s)\n", status.ExpiresAt.Format(time.DateTime+" MST"), utils.FormatSeconds(max(expiresIn, 0)))
 	} else {
-		r.printer.Println("Not logged in.")
+		r.printer.Println("Expires:       never")
+	}
+
+	if status.RefreshToken {
+		r.printer.Println("Refresh token: yes, the token is refreshed automatically")
+	} else {
+		r.printer.Println("Refresh token: no")
 	}
 
 	return nil
@@ -145,6 +286,122 @@ func (r *Root) RunAuthLogout(cmd *cobra.Command, _ []string) error {
 	return nil
 }
 
+func (r *Root) RunAuthProfilesList(_ *cobra.Command, _ []string) error {
+	active := r.storage.ProfileName()
+	names := r.storage.ProfileNames()
+
+	if !slices.Contains(names, active) {
+		names = append(names, active)
+		slices.Sort(names)
+	}
+
+	for _, name := range names {
+		prefix := "  "
+
+		if name == active {
+			prefix = "* "
+		}
+
+		profile := r.storage.GetProfileByName(name)
+
+		if profile == nil || profile.Token == nil {
+			r.printer.Printf("%s%s (not logged in)\n", prefix, name)
+		} else {
+			r.printer.Printf("%s%s\n", prefix, name)
+		}
+	}
+
+	return nil
+}
+
+func (r *Root) RunAuthProfilesUse(cmd *cobra.Command, args []string) error {
+	err := r.storage.UseProfile(args[0])
+
+	if err != nil {
+		cmd.SilenceUsage = true
+
+		return fmt.Errorf("%w: %s", err, args[0])
+	}
+
+	r.printer.Printf("Default profile set to %s.\n", args[0])
+
+	return nil
+}
+
+func (r *Root) RunAuthProfilesDelete(cmd *cobra.Command, args []string) error {
+	ctx := cmd.Context()
+	profile := r.storage.GetProfileByName(args[0])
+
+	if profile == nil {
+		cmd.SilenceUsage = true
+
+		return fmt.Errorf("%w: %s", storage.ErrProfileNotFound, args[0])
+	}
+
+	if profile.Token != nil {
+		_ = r.client.RevokeToken(ctx, profile.Token.RefreshToken)
+	}
+
+	err := r.storage.DeleteProfile(args[0])
+
+	if err != nil {
+		cmd.SilenceUsage = true
+
+		return err
+	}
+
+	r.printer.Printf("Profile %s deleted.\n", args[0])
+
+	return nil
+}
+
+// Returns where the token in use comes from. The environment variable takes precedence over the profile.
+func (r *Root) authStatus() *authStatusOutput {
+	if r.ctx.TokenFromEnv {
+		return &authStatusOutput{Source: "env"}
+	}
+
+	if r.storage == nil {
+		return &authStatusOutput{}
+	}
+
+	status := &authStatusOutput{}
+	profile := r.storage.GetProfile()
+
+	if profile.Token != nil {
+		status.Source = "profile"
+		status.Profile = r.storage.ProfileName()
+		status.RefreshToken = profile.Token.RefreshToken != ""
+	}
+
+	return status
+}
+
+func (r *Root) loginWithDevice(ctx context.Context) error {
+	device, err := r.client.AuthorizeDevice(ctx)
+
+	if err != nil {
+		return err
+	}
+
+	r.printer.Printf("Please visit %s and enter the code: %s\n", device.VerificationURI, device.UserCode)
+
+	if device.VerificationURIComplete != "" {
+		r.printer.Printf("Alternatively, open the following URL to skip entering the code:\n%s\n", device.VerificationURIComplete)
+	}
+
+	r.printer.Println("\nWaiting for approval...")
+	err = r.client.AwaitDeviceToken(ctx, device)
+
+	if err != nil {
+		return err
+	}
+
+	r.printer.Println("Success! You are now authenticated.")
+
+	return nil
+}
+
 func (r *Root) loginWithToken(ctx context.Context) error {
 	r.printer.Println("Please enter your token:")
 	token, err := r.printer.ReadPassword()
@@ -7,6 +7,7 @@ import (
 	"os"
 	"syscall"
 	"testing"
+	"time"
 
 	"github.com/jsdelivr/globalping-cli/api"
 	apiMocks "github.com/jsdelivr/globalping-cli/mocks/api"
@@ -94,7 +95,48 @@ func Test_Auth_Login(t *testing.T) {
 	assert.Equal(t, `Please visit the following URL to authenticate:
 http://localhost
 
-Can't use the browser-based flow? Use "globalping auth login --with-token" to read a token from stdin instead.
+Can't use the browser-based flow? Use "globalping auth login --device" to log in from a different device, or "globalping auth login --with-token" to read a token from stdin instead.
+`, w.String())
+}
+
+func Test_Auth_Login_Device(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	gbMock := apiMocks.NewMockClient(ctrl)
+	utilsMock := utilsMocks.NewMockUtils(ctrl)
+
+	w := new(bytes.Buffer)
+	printer := view.NewPrinter(nil, w, w)
+	ctx := createDefaultContext()
+	_storage := createDefaultTestStorage(t, utilsMock)
+	_storage.GetProfile().Token = &storage.Token{
+		AccessToken:  "oldToken",
+		RefreshToken: "oldRefreshToken",
+	}
+
+	root := NewRoot(printer, ctx, nil, utilsMock, gbMock, nil, _storage)
+
+	device := &api.DeviceAuthorizationResponse{
+		DeviceCode:              "d3vice",
+		UserCode:                "ABCD-EFGH",
+		VerificationURI:         "https://dash.globalping.io/device",
+		VerificationURIComplete: "https://dash.globalping.io/device?code=ABCD-EFGH",
+	}
+	gbMock.EXPECT().AuthorizeDevice(t.Context()).Return(device, nil)
+	gbMock.EXPECT().AwaitDeviceToken(t.Context(), device).Return(nil)
+	gbMock.EXPECT().RevokeToken(t.Context(), "oldRefreshToken").Return(nil)
+
+	os.Args = []string{"globalping", "auth", "login", "--device"}
+	err := root.Cmd.ExecuteContext(t.Context())
+	assert.NoError(t, err)
+
+	assert.Equal(t, `Please visit https://dash.globalping.io/device and enter the code: ABCD-EFGH
+Alternatively, open the following URL to skip entering the code:
+https://dash.globalping.io/device?code=ABCD-EFGH
+
+Waiting for approval...
+Success! You are now authenticated.
 `, w.String())
 }
 
@@ -104,15 +146,26 @@ func Test_AuthStatus(t *testing.T) {
 
 	gbMock := apiMocks.NewMockClient(ctrl)
 
+	utilsMock := utilsMocks.NewMockUtils(ctrl)
+	utilsMock.EXPECT().Now().Return(defaultCurrentTime).AnyTimes()
+
 	w := new(bytes.Buffer)
 	printer := view.NewPrinter(nil, w, w)
 	ctx := createDefaultContext()
+	_storage := createDefaultTestStorage(t, utilsMock)
+	_storage.GetProfile().Token = &storage.Token{
+		AccessToken:  "token",
+		RefreshToken: "refreshToken",
+	}
 
-	root := NewRoot(printer, ctx, nil, nil, gbMock, nil, nil)
+	root := NewRoot(printer, ctx, nil, utilsMock, gbMock, nil, _storage)
 
 	gbMock.EXPECT().TokenIntrospection(t.Context(), "").Return(&api.IntrospectionResponse{
 		Active:   true,
 		Username: "test",
+		ClientID: "globalping-cli",
+		Scope:    "measurements probes",
+		Exp:      defaultCurrentTime.Add(2 * time.Hour).Unix(),
 	}, nil)
 
 	os.Args = []string{"globalping", "auth", "status"}
@@ -120,6 +173,72 @@ func Test_AuthStatus(t *testing.T) {
 	assert.NoError(t, err)
 
 	assert.Equal(t, `Logged in as test.
+
+Token source:  stored in the "default" profile
+Client:        globalping-cli
+Scopes:        measurements, probes
+Expires:       `+defaultCurrentTime.Add(2*time.Hour).UTC().Format(time.DateTime+" MST")+` (in 2 hours)
+Refresh token: yes, the token is refreshed automatically
+`, w.String())
+}
+
+func Test_AuthStatus_Env_JSON(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	gbMock := apiMocks.NewMockClient(ctrl)
+
+	w := new(bytes.Buffer)
+	printer := view.NewPrinter(nil, w, w)
+	ctx := createDefaultContext()
+	ctx.TokenFromEnv = true
+
+	root := NewRoot(printer, ctx, nil, nil, gbMock, nil, nil)
+
+	gbMock.EXPECT().TokenIntrospection(t.Context(), "").Return(&api.IntrospectionResponse{
+		Active:   true,
+		Username: "test",
+		Exp:      defaultCurrentTime.Unix(),
+	}, nil)
+
+	os.Args = []string{"globalping", "auth", "status", "--json"}
+	err := root.Cmd.ExecuteContext(t.Context())
+	assert.NoError(t, err)
+
+	assert.Equal(t, `{
+  "logged_in": true,
+  "username": "test",
+  "expires_at": "`+defaultCurrentTime.UTC().Format(time.RFC3339)+`",
+  "refresh_token": false,
+  "source": "env"
+}
+`, w.String())
+}
+
+func Test_AuthStatus_Rejected(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	gbMock := apiMocks.NewMockClient(ctrl)
+
+	w := new(bytes.Buffer)
+	printer := view.NewPrinter(nil, w, w)
+	ctx := createDefaultContext()
+	ctx.TokenFromEnv = true
+
+	root := NewRoot(printer, ctx, nil, nil, gbMock, nil, nil)
+
+	gbMock.EXPECT().TokenIntrospection(t.Context(), "").Return(&api.IntrospectionResponse{
+		Active: false,
+	}, nil)
+
+	os.Args = []string{"globalping", "auth", "status"}
+	err := root.Cmd.ExecuteContext(t.Context())
+	assert.NoError(t, err)
+
+	assert.Equal(t, `Not logged in.
+
+Token source:  GLOBALPING_TOKEN environment variable
 `, w.String())
 }
 
@@ -143,3 +262,115 @@ func Test_Logout(t *testing.T) {
 
 	assert.Equal(t, "You are now logged out.\n", w.String())
 }
+
+func Test_Auth_Login_WithToken_Profile(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	gbMock := apiMocks.NewMockClient(ctrl)
+
+	utilsMock := utilsMocks.NewMockUtils(ctrl)
+	utilsMock.EXPECT().Now().Return(defaultCurrentTime).AnyTimes()
+
+	w := new(bytes.Buffer)
+	r := new(bytes.Buffer)
+	r.WriteString("token\n")
+	printer := view.NewPrinter(r, w, w)
+	ctx := createDefaultContext()
+	_storage := createDefaultTestStorage(t, utilsMock)
+	_storage.GetProfile().Token = &storage.Token{
+		AccessToken:  "oldToken",
+		RefreshToken: "oldRefreshToken",
+	}
+
+	root := NewRoot(printer, ctx, nil, utilsMock, gbMock, nil, _storage)
+
+	gbMock.EXPECT().TokenIntrospection(t.Context(), "token").Return(&api.IntrospectionResponse{
+		Active:   true,
+		Username: "test",
+	}, nil)
+
+	os.Args = []string{"globalping", "auth", "login", "--with-token", "--profile", "work"}
+	err := root.Cmd.ExecuteContext(t.Context())
+	assert.NoError(t, err)
+
+	assert.Equal(t, `Please enter your token:
+Logged in as test.
+`, w.String())
+
+	assert.Equal(t, &storage.Profile{
+		Token: &storage.Token{
+			AccessToken: "token",
+			Expiry:      defaultCurrentTime.Add(math.MaxInt64),
+		},
+	}, _storage.GetProfileByName("work"))
+	assert.Equal(t, &storage.Profile{
+		Token: &storage.Token{
+			AccessToken:  "oldToken",
+			RefreshToken: "oldRefreshToken",
+		},
+	}, _storage.GetProfileByName("default"))
+}
+
+func Test_Auth_Profiles(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	gbMock := apiMocks.NewMockClient(ctrl)
+
+	w := new(bytes.Buffer)
+	printer := view.NewPrinter(nil, w, w)
+	ctx := createDefaultContext()
+	_storage := createDefaultTestStorage(t, nil)
+	_storage.GetProfile().Token = &storage.Token{
+		AccessToken:  "token",
+		RefreshToken: "refreshToken",
+	}
+	assert.NoError(t, _storage.SelectProfile("work"))
+	_storage.GetProfile().Token = &storage.Token{
+		AccessToken:  "workToken",
+		RefreshToken: "workRefreshToken",
+	}
+	assert.NoError(t, _storage.SelectProfile("personal"))
+	_storage.GetProfile()
+	assert.NoError(t, _storage.UseProfile("default"))
+
+	root := NewRoot(printer, ctx, nil, nil, gbMock, nil, _storage)
+
+	os.Args = []string{"globalping", "auth", "profiles", "list"}
+	err := root.Cmd.ExecuteContext(t.Context())
+	assert.NoError(t, err)
+	assert.Equal(t, `* default
+  personal (not logged in)
+  work
+`, w.String())
+
+	w.Reset()
+	os.Args = []string{"globalping", "auth", "profiles", "use", "work"}
+	err = root.Cmd.ExecuteContext(t.Context())
+	assert.NoError(t, err)
+	assert.Equal(t, "Default profile set to work.\n", w.String())
+	assert.Equal(t, "work", _storage.ProfileName())
+
+	gbMock.EXPECT().RevokeToken(t.Context(), "workRefreshToken").Return(nil)
+
+	w.Reset()
+	os.Args = []string{"globalping", "auth", "profiles", "delete", "work"}
+	err = root.Cmd.ExecuteContext(t.Context())
+	assert.NoError(t, err)
+	assert.Equal(t, "Profile work deleted.\n", w.String())
+	assert.Equal(t, []string{"default", "personal"}, _storage.ProfileNames())
+	assert.Equal(t, "default", _storage.ProfileName())
+
+	w.Reset()
+	os.Args = []string{"globalping", "auth", "profiles", "use", "unknown"}
+	err = root.Cmd.ExecuteContext(t.Context())
+	assert.EqualError(t, err, "profile not found: unknown")
+}
+
+func Test_ParseProfileFlag(t *testing.T) {
+	assert.Equal(t, "", parseProfileFlag([]string{"ping", "google.com", "from", "Berlin"}, ""))
+	assert.Equal(t, "env", parseProfileFlag([]string{"ping", "google.com", "-L", "2"}, "env"))
+	assert.Equal(t, "work", parseProfileFlag([]string{"ping", "google.com", "--profile", "work", "--limit", "2"}, "env"))
+	assert.Equal(t, "work", parseProfileFlag([]string{"auth", "login", "--profile=work", "--with-token"}, ""))
+}
@@ -0,0 +1,165 @@
+package cmd
+
+import (
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/jsdelivr/globalping-cli/storage"
+	"github.com/jsdelivr/globalping-cli/utils"
+	"github.com/spf13/cobra"
+)
+
+var (
+	ErrInvalidTag = errors.New("invalid tag")
+)
+
+func (r *Root) initBookmark() {
+	bookmarkCmd := &cobra.Command{
+		RunE:  r.RunBookmark,
+		Use:   "bookmark [measurement ID | @1 | first | @-1 | last | previous]",
+		Short: "Bookmark a measurement to reuse its probes later",
+		Long: `Bookmark a measurement with tags and a note. Bookmarks are kept permanently and are displayed next to the measurement in the history.
+Use "#tag" as the location of a new measurement to run it with the probes of the most recent bookmark with this tag.
+
+Examples:
+  # Bookmark the last measurement of this session.
+  bookmark last --tag incident-4312 --note "before failover"
+
+  # Bookmark a measurement by its ID.
+  bookmark rvasVvKnj48cxNjC --tag eu-edge
+
+  # Ping google.com using the probes of the bookmark tagged "incident-4312". The quotes prevent the shell from treating # as a comment.
+  ping google.com from "#incident-4312"
+
+  # List all bookmarks tagged "incident-4312".
+  bookmark list --tag incident-4312`,
+		Args: cobra.ExactArgs(1),
+	}
+
+	bookmarkFlags := bookmarkCmd.Flags()
+	bookmarkFlags.StringArray("tag", nil, "add a tag to the bookmark; to add multiple tags, define the flag for each one separately")
+	bookmarkFlags.String("note", "", "add a note to the bookmark")
+
+	listCmd := &cobra.Command{
+		RunE:  r.RunBookmarkList,
+		Use:   "list",
+		Short: "List the bookmarks",
+		Long:  `List the bookmarks, the oldest first.`,
+		Args:  cobra.NoArgs,
+	}
+
+	listCmd.Flags().String("tag", "", "only list bookmarks with this tag")
+
+	deleteCmd := &cobra.Command{
+		RunE:  r.RunBookmarkDelete,
+		Use:   "delete [measurement ID]",
+		Short: "Delete a bookmark",
+		Long:  `Delete a bookmark.`,
+		Args:  cobra.ExactArgs(1),
+	}
+
+	bookmarkCmd.AddCommand(listCmd)
+	bookmarkCmd.AddCommand(deleteCmd)
+
+	r.Cmd.AddCommand(bookmarkCmd)
+}
+
+func (r *Root) RunBookmark(cmd *cobra.Command, args []string) error {
+	tags, _ := cmd.Flags().GetStringArray("tag")
+	note, _ := cmd.Flags().GetString("note")
+
+	for _, tag := range tags {
+		if !isValidTag(tag) {
+			return fmt.Errorf("%w: %q", ErrInvalidTag, tag)
+		}
+	}
+
+	cmd.SilenceUsage = true
+	id, err := r.mapFromSession(args[0])
+
+	if err != nil {
+		return err
+	}
+
+	if id == "" {
+		id = args[0]
+	}
+
+	err = r.storage.SaveBookmark(&storage.Bookmark{
+		ID:        id,
+		Tags:      tags,
+		Note:      note,
+		Command:   r.storage.GetHistoryCommand(id),
+		Session:   r.storage.CurrentSessionID(),
+		CreatedAt: r.utils.Now(),
+	})
+
+	if err != nil {
+		return fmt.Errorf("failed to save bookmark: %w", err)
+	}
+
+	r.printer.Printf("Bookmarked measurement %s.\n", id)
+
+	return nil
+}
+
+func (r *Root) RunBookmarkList(cmd *cobra.Command, _ []string) error {
+	cmd.SilenceUsage = true
+	tag, _ := cmd.Flags().GetString("tag")
+	tag = strings.TrimPrefix(tag, "#")
+	bookmarks, err := r.storage.GetBookmarks()
+
+	if err != nil {
+		return err
+	}
+
+	found := false
+
+	for _, b := range bookmarks {
+		if tag != "" && !slices.Contains(b.Tags, tag) {
+			continue
+		}
+
+		found = true
+		command := b.Command
+
+		if command == "" {
+			command = "-"
+		}
+
+		r.printer.Printf("%s | %s | %s", b.ID, b.CreatedAt.Local().Format("2006-01-02 15:04:05"), command)
+
+		if label := b.Label(); label != "" {
+			r.printer.Printf(" | %s", label)
+		}
+
+		r.printer.Printf("\n> %s%s\n", utils.ShareURL, b.ID)
+	}
+
+	if !found {
+		r.printer.Println("No bookmarks found")
+	}
+
+	return nil
+}
+
+func (r *Root) RunBookmarkDelete(cmd *cobra.Command, args []string) error {
+	cmd.SilenceUsage = true
+	err := r.storage.DeleteBookmark(args[0])
+
+	if err != nil {
+		return fmt.Errorf("%w: %s", err, args[0])
+	}
+
+	r.printer.Printf("Bookmark %s deleted.\n", args[0])
+
+	return nil
+}
+
+func isValidTag(tag string) bool {
+	return tag != "" && !strings.ContainsFunc(tag, func(r rune) bool {
+		return r <= ' ' || r == ',' || r == '#'
+	})
+}
@@ -0,0 +1,84 @@
+package cmd
+
+import (
+	"bytes"
+	"os"
+	"testing"
+
+	utilsMocks "github.com/jsdelivr/globalping-cli/mocks/utils"
+	"github.com/jsdelivr/globalping-cli/storage"
+	"github.com/jsdelivr/globalping-cli/view"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/mock/gomock"
+)
+
+func Test_Bookmark(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	utilsMock := utilsMocks.NewMockUtils(ctrl)
+	utilsMock.EXPECT().Now().Return(defaultCurrentTime).AnyTimes()
+
+	w := new(bytes.Buffer)
+	printer := view.NewPrinter(nil, w, w)
+	ctx := createDefaultContext()
+	_storage := createDefaultTestStorage(t, utilsMock)
+	assert.NoError(t, _storage.SaveIdToSession(measurementID1))
+	assert.NoError(t, _storage.SaveCommandToHistory("1", defaultCurrentTime.Unix(), measurementID1, "ping jsdelivr.com"))
+
+	root := NewRoot(printer, ctx, nil, utilsMock, nil, nil, _storage)
+	os.Args = []string{"globalping", "bookmark", "last", "--tag", "incident-4312", "--note", "before failover"}
+	err := root.Cmd.ExecuteContext(t.Context())
+	assert.NoError(t, err)
+	assert.Equal(t, "Bookmarked measurement "+measurementID1+".\n", w.String())
+
+	bookmarks, err := _storage.GetBookmarks()
+	assert.NoError(t, err)
+	assert.Equal(t, []*storage.Bookmark{
+		{
+			ID:        measurementID1,
+			Tags:      []string{"incident-4312"},
+			Note:      "before failover",
+			Command:   "ping jsdelivr.com",
+			Session:   _storage.CurrentSessionID(),
+			CreatedAt: defaultCurrentTime,
+		},
+	}, bookmarks)
+
+	root = NewRoot(printer, ctx, nil, utilsMock, nil, nil, _storage)
+	os.Args = []string{"globalping", "bookmark", measurementID2, "--tag", "other"}
+	err = root.Cmd.ExecuteContext(t.Context())
+	assert.NoError(t, err)
+
+	w.Reset()
+	root = NewRoot(printer, ctx, nil, utilsMock, nil, nil, _storage)
+	os.Args = []string{"globalping", "bookmark", "list", "--tag", "incident-4312"}
+	err = root.Cmd.ExecuteContext(t.Context())
+	assert.NoError(t, err)
+	assert.Equal(t, measurementID1+" | "+defaultCurrentTime.Local().Format("2006-01-02 15:04:05")+" | ping jsdelivr.com | #incident-4312 before failover\n"+
+		"> https://globalping.io?measurement="+measurementID1+"\n", w.String())
+
+	w.Reset()
+	os.Args = []string{"globalping", "history"}
+	err = root.Cmd.ExecuteContext(t.Context())
+	assert.NoError(t, err)
+	assert.Equal(t, createDefaultExpectedHistoryItem("1", "ping jsdelivr.com | bookmarked: #incident-4312 before failover", measurementID1)+"\n", w.String())
+
+	id, err := root.mapFromSession("#other")
+	assert.NoError(t, err)
+	assert.Equal(t, measurementID2, id)
+
+	_, err = root.mapFromSession("#unknown")
+	assert.EqualError(t, err, "bookmark not found: #unknown")
+
+	root = NewRoot(printer, ctx, nil, utilsMock, nil, nil, _storage)
+	os.Args = []string{"globalping", "bookmark", "last", "--tag", "two words"}
+	err = root.Cmd.ExecuteContext(t.Context())
+	assert.ErrorIs(t, err, ErrInvalidTag)
+
+	w.Reset()
+	os.Args = []string{"globalping", "bookmark", "delete", measurementID2}
+	err = root.Cmd.ExecuteContext(t.Context())
+	assert.NoError(t, err)
+	assert.Equal(t, "Bookmark "+measurementID2+" deleted.\n", w.String())
+}
@@ -12,7 +12,6 @@ import (
 	"strings"
 	"time"
 
-	"github.com/jsdelivr/globalping-cli/api"
 	"github.com/jsdelivr/globalping-cli/storage"
 	"github.com/jsdelivr/globalping-cli/version"
 	"github.com/jsdelivr/globalping-cli/view"
@@ -32,6 +31,18 @@ func (r *Root) handleMeasurement(ctx context.Context, id string, opts *globalpin
 		}
 	}()
 
+	// The hook runs after the results are printed
+	var finished *globalping.Measurement
+
+	defer func() {
+		if finished != nil {
+			r.runMeasurementHook(ctx, finished, r.printer.ErrWriter)
+		} else if err != nil && !errors.Is(err, context.Canceled) {
+			// The measurement was created, but its results could not be retrieved
+			r.runMeasurementErrorHook(id, opts, err, r.printer.ErrWriter)
+		}
+	}()
+
 	if !r.ctx.Table && (r.ctx.CIMode || r.ctx.ToJSON || r.ctx.ToLatency) {
 		res, err := r.client.AwaitMeasurement(ctx, id)
 
@@ -39,6 +50,8 @@ func (r *Root) handleMeasurement(ctx context.Context, id string, opts *globalpin
 			return err
 		}
 
+		finished = res
+
 		if r.ctx.ToLatency {
 			return r.viewer.OutputLatency(id, res)
 		}
@@ -74,6 +87,10 @@ func (r *Root) handleMeasurement(ctx context.Context, id string, opts *globalpin
 
 	if r.ctx.Table {
 		for {
+			if res.Status != globalping.MeasurementStatusInProgress {
+				finished = res
+			}
+
 			if !r.ctx.CIMode || res.Status != globalping.MeasurementStatusInProgress {
 				_, err = r.viewer.OutputTable(res)
 
@@ -122,12 +139,30 @@ func (r *Root) handleMeasurement(ctx context.Context, id string, opts *globalpin
 	}
 
 	r.printer.AreaClear()
+	finished = res
 
 	r.viewer.OutputDefault(id, res, opts)
 
 	return nil
 }
 
+// Returns true if stdout is a terminal, which supports realtime updates.
+func (r *Root) isOutputTerminal() (bool, error) {
+	f, ok := r.printer.OutWriter.(*os.File)
+
+	if !ok {
+		return false, nil
+	}
+
+	stdoutFileInfo, err := f.Stat()
+
+	if err != nil {
+		return false, fmt.Errorf("stdout stat failed: %w", err)
+	}
+
+	return stdoutFileInfo.Mode()&os.ModeCharDevice != 0, nil
+}
+
 func (r *Root) updateContext(cmd *cobra.Command, args []string) error {
 	r.ctx.Cmd = cmd.CalledAs() // Get the command name
 
@@ -155,6 +190,13 @@ func (r *Root) updateContext(cmd *cobra.Command, args []string) error {
 
 	r.ctx.Target = targetQuery.Target
 
+	// An output flag overrides the default output format defined in the settings
+	if cmd.Flags().Changed("json") || cmd.Flags().Changed("latency") || cmd.Flags().Changed("table") {
+		r.ctx.ToJSON = r.ctx.ToJSON && cmd.Flags().Changed("json")
+		r.ctx.ToLatency = r.ctx.ToLatency && cmd.Flags().Changed("latency")
+		r.ctx.Table = r.ctx.Table && cmd.Flags().Changed("table")
+	}
+
 	if r.ctx.Table {
 		r.ctx.ToLatency = false
 		r.ctx.ToJSON = false
@@ -189,20 +231,14 @@ func (r *Root) updateContext(cmd *cobra.Command, args []string) error {
 
 	// Check if it is a terminal or being piped/redirected
 	// We want to disable realtime updates if that is the case
-	f, ok := r.printer.OutWriter.(*os.File)
-
-	if ok {
-		stdoutFileInfo, err := f.Stat()
+	terminal, err := r.isOutputTerminal()
 
-		if err != nil {
-			return fmt.Errorf("stdout stat failed: %w", err)
-		}
+	if err != nil {
+		return err
+	}
 
-		if (stdoutFileInfo.Mode() & os.ModeCharDevice) == 0 {
-			// stdout is piped, run in ci mode
-			r.ctx.CIMode = true
-		}
-	} else {
+	if !terminal {
+		// stdout is piped, run in ci mode
 		r.ctx.CIMode = true
 	}
 
@@ -255,13 +291,6 @@ func (r *Root) evaluateError(err error) {
 		return
 	}
 
-	if measurementErr.StatusCode == api.StatusUnauthorizedWithTokenRefreshed {
-		r.Cmd.SilenceErrors = true
-		r.printer.ErrPrintln("Access token successfully refreshed. Try repeating the measurement.")
-
-		return
-	}
-
 	if measurementErr.StatusCode == http.StatusTooManyRequests && r.ctx.MeasurementsCreated > 0 {
 		r.Cmd.SilenceErrors = true
 		r.printer.ErrPrintln(r.printer.Color("> "+measurementErr.Message, view.FGBrightYellow))
@@ -336,7 +365,7 @@ func findAndRemoveResolver(args []string) (string, []string) {
 	return resolver, argsWithoutResolver
 }
 
-// Maps a location to a measurement ID from history, if possible.
+// Maps a location to a measurement ID from history or bookmarks, if possible.
 func (r *Root) mapFromSession(location string) (string, error) {
 	if location == "" {
 		return "", nil
@@ -360,6 +389,16 @@ func (r *Root) mapFromSession(location string) (string, error) {
 		return r.storage.GetIdFromSession(-1)
 	}
 
+	if location[0] == '#' {
+		b, err := r.storage.GetBookmarkByTag(location[1:])
+
+		if err != nil {
+			return "", fmt.Errorf("%w: %s", err, location)
+		}
+
+		return b.ID, nil
+	}
+
 	return "", nil
 }
 
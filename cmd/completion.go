@@ -0,0 +1,215 @@
+package cmd
+
+import (
+	"context"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/jsdelivr/globalping-go"
+	"github.com/spf13/cobra"
+)
+
+var (
+	dnsQueryTypes = []string{"A", "AAAA", "ANY", "CNAME", "DNSKEY", "DS", "HTTPS", "MX", "NS", "NSEC", "PTR", "RRSIG", "SOA", "SRV", "SVCB", "TXT"}
+	httpMethods   = []string{"HEAD", "GET", "OPTIONS"}
+
+	// The probe list is fetched only if it isn't cached, so completion stays fast
+	completionProbesTimeout = 3 * time.Second
+)
+
+// Registers the completion functions for the arguments and flags of the measurement commands.
+func (r *Root) initCompletions() {
+	protocols := map[string][]string{
+		"dns":        globalping.DNSProtocols,
+		"http":       globalping.HTTPProtocols,
+		"mtr":        globalping.MTRProtocols,
+		"ping":       globalping.PingProtocols,
+		"traceroute": globalping.TracerouteProtocols,
+	}
+
+	for _, cmd := range r.Cmd.Commands() {
+		if cmd.Name() == "share" {
+			cmd.ValidArgsFunction = r.completeSessionReferences
+		}
+
+		values, ok := protocols[cmd.Name()]
+
+		if !ok {
+			continue
+		}
+
+		cmd.ValidArgsFunction = r.completeMeasurementArgs
+		_ = cmd.RegisterFlagCompletionFunc("protocol", fixedCompletion(values))
+
+		// The --from flag is shared by all measurement commands, so it's registered only once
+		if cmd.Name() == "ping" {
+			_ = cmd.RegisterFlagCompletionFunc("from", r.completeFromFlag)
+		}
+
+		switch cmd.Name() {
+		case "dns":
+			_ = cmd.RegisterFlagCompletionFunc("type", fixedCompletion(dnsQueryTypes))
+		case "http":
+			_ = cmd.RegisterFlagCompletionFunc("method", fixedCompletion(httpMethods))
+		}
+	}
+}
+
+// Completes "[target] from [location]". Locations may contain spaces, so all arguments after "from" are treated as one location.
+func (r *Root) completeMeasurementArgs(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+	if len(args) == 0 {
+		return nil, cobra.ShellCompDirectiveNoFileComp
+	}
+
+	if len(args) == 1 {
+		return filterCompletions([]string{"from"}, toComplete), cobra.ShellCompDirectiveNoFileComp
+	}
+
+	if args[1] != "from" {
+		return nil, cobra.ShellCompDirectiveNoFileComp
+	}
+
+	typed := ""
+
+	if len(args) > 2 {
+		typed = strings.Join(args[2:], " ") + " "
+	}
+
+	completions := make([]string, 0)
+
+	for _, c := range r.completeLocation(cmd.Context(), typed+toComplete) {
+		// Only the current word is replaced by the shell
+		if len(c) >= len(typed) {
+			completions = append(completions, c[len(typed):])
+		}
+	}
+
+	return completions, cobra.ShellCompDirectiveNoFileComp | cobra.ShellCompDirectiveNoSpace
+}
+
+func (r *Root) completeSessionReferences(_ *cobra.Command, _ []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+	return filterCompletions(r.sessionReferences(true), toComplete), cobra.ShellCompDirectiveNoFileComp
+}
+
+func (r *Root) completeFromFlag(cmd *cobra.Command, _ []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+	return r.completeLocation(cmd.Context(), toComplete), cobra.ShellCompDirectiveNoFileComp | cobra.ShellCompDirectiveNoSpace
+}
+
+// Returns the completions for the last part of a location list, e.g. "Germany,Fra" completes to "Germany,Frankfurt".
+func (r *Root) completeLocation(ctx context.Context, value string) []string {
+	i := strings.LastIndexAny(value, ",+")
+	prefix, last := value[:i+1], value[i+1:]
+	candidates := r.sessionReferences(i == -1)
+
+	if ctx == nil {
+		ctx = context.Background()
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, completionProbesTimeout)
+	defer cancel()
+
+	probes, _ := r.loadProbes(ctx, r.client != nil)
+	candidates = append(candidates, locationNames(probes)...)
+	completions := make([]string, 0)
+
+	for _, c := range filterCompletions(candidates, last) {
+		completions = append(completions, prefix+c)
+	}
+
+	return completions
+}
+
+// Returns the references to previous measurements and bookmarks, which can only be used as the whole location.
+// The measurement IDs are described with their commands.
+func (r *Root) sessionReferences(wholeLocation bool) []string {
+	if r.storage == nil || !wholeLocation {
+		return nil
+	}
+
+	refs := make([]string, 0)
+
+	if bookmarks, err := r.storage.GetBookmarks(); err == nil {
+		for _, b := range bookmarks {
+			for _, tag := range b.Tags {
+				if !slices.Contains(refs, "#"+tag) {
+					refs = append(refs, "#"+tag)
+				}
+			}
+		}
+	}
+
+	b, err := r.storage.GetMeasurements()
+
+	if err != nil || len(b) == 0 {
+		return refs
+	}
+
+	ids := strings.Split(strings.TrimSpace(string(b)), "\n")
+	refs = append(refs, "first", "last", "previous")
+
+	for i, id := range ids {
+		description := r.storage.GetHistoryCommand(id)
+		refs = append(refs, describeCompletion("@"+strconv.Itoa(i+1), description), describeCompletion(id, description))
+	}
+
+	return refs
+}
+
+// Returns the location names of the probes, without duplicates.
+// Names containing separators, such as some network names, can't be used as locations and are skipped.
+func locationNames(probes []globalping.Probe) []string {
+	names := make([]string, 0)
+
+	for _, p := range probes {
+		l := p.Location
+		names = append(names,
+			l.Continent,
+			continentNames[l.Continent],
+			l.Region,
+			l.Country,
+			countryNames[l.Country],
+			usStateNames[l.State],
+			l.City,
+			l.Network,
+			"AS"+strconv.Itoa(l.ASN),
+		)
+		names = append(names, p.Tags...)
+	}
+
+	slices.Sort(names)
+
+	return slices.DeleteFunc(slices.Compact(names), func(name string) bool {
+		return name == "" || name == "AS0" || strings.ContainsAny(name, ",+")
+	})
+}
+
+// Returns the values starting with the prefix, ignoring case.
+func filterCompletions(values []string, prefix string) []string {
+	filtered := make([]string, 0)
+	prefix = strings.ToLower(prefix)
+
+	for _, v := range values {
+		if strings.HasPrefix(strings.ToLower(v), prefix) {
+			filtered = append(filtered, v)
+		}
+	}
+
+	return filtered
+}
+
+// Adds a description, which is shown next to the value by zsh and fish.
+func describeCompletion(value string, description string) string {
+	if description == "" {
+		return value
+	}
+
+	return value + "\t" + description
+}
+
+func fixedCompletion(values []string) cobra.CompletionFunc {
+	return func(_ *cobra.Command, _ []string, toComplete string) ([]cobra.Completion, cobra.ShellCompDirective) {
+		return filterCompletions(values, toComplete), cobra.ShellCompDirectiveNoFileComp
+	}
+}
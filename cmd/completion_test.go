@@ -0,0 +1,103 @@
+package cmd
+
+import (
+	"bytes"
+	"encoding/json"
+	"os"
+	"testing"
+
+	utilsMocks "github.com/jsdelivr/globalping-cli/mocks/utils"
+	"github.com/jsdelivr/globalping-cli/storage"
+	"github.com/jsdelivr/globalping-cli/view"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/mock/gomock"
+)
+
+func Test_Completion_Locations(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	utilsMock := utilsMocks.NewMockUtils(ctrl)
+	utilsMock.EXPECT().Now().Return(defaultCurrentTime).AnyTimes()
+
+	_storage := createDefaultTestStorage(t, utilsMock)
+	b, _ := json.Marshal(createProbesResponse())
+	assert.NoError(t, _storage.SaveCachedProbes(b))
+
+	tests := []struct {
+		args     []string
+		expected string
+	}{
+		{[]string{"ping", "jsdelivr.com", ""}, "from\n:4\n"},
+		{[]string{"ping", "jsdelivr.com", "from", "Fra"}, "Frankfurt\n:6\n"},
+		{[]string{"mtr", "jsdelivr.com", "from", "Western", "Eu"}, "Europe\n:6\n"},
+		{[]string{"traceroute", "jsdelivr.com", "from", "germany,fal"}, "germany,Falkenstein\n:6\n"},
+		{[]string{"http", "jsdelivr.com", "--from", "Europe+as1"}, "Europe+AS16509\n:6\n"},
+		{[]string{"ping", "jsdelivr.com", "from", "Amazon"}, ":6\n"},
+		{[]string{"dns", "jsdelivr.com", "--type", "AA"}, "AAAA\n:4\n"},
+		{[]string{"http", "jsdelivr.com", "--method", "g"}, "GET\n:4\n"},
+		{[]string{"traceroute", "jsdelivr.com", "--protocol", ""}, "ICMP\nTCP\nUDP\n:4\n"},
+	}
+
+	for _, test := range tests {
+		w := new(bytes.Buffer)
+		printer := view.NewPrinter(nil, w, new(bytes.Buffer))
+		root := NewRoot(printer, createDefaultContext(), nil, utilsMock, nil, nil, _storage)
+
+		os.Args = append([]string{"globalping", "__complete"}, test.args...)
+		err := root.Cmd.ExecuteContext(t.Context())
+		assert.NoError(t, err)
+		assert.Equal(t, test.expected, w.String(), test.args)
+	}
+}
+
+func Test_Completion_SessionReferences(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	utilsMock := utilsMocks.NewMockUtils(ctrl)
+	utilsMock.EXPECT().Now().Return(defaultCurrentTime).AnyTimes()
+
+	_storage := createDefaultTestStorage(t, utilsMock)
+	assert.NoError(t, _storage.SaveCachedProbes([]byte(`[]`)))
+	assert.NoError(t, _storage.SaveIdToSession(measurementID1))
+	assert.NoError(t, _storage.SaveCommandToHistory("1", defaultCurrentTime.Unix(), measurementID1, "ping jsdelivr.com"))
+	assert.NoError(t, _storage.SaveBookmark(&storage.Bookmark{ID: measurementID1, Tags: []string{"baseline"}}))
+
+	w := new(bytes.Buffer)
+	printer := view.NewPrinter(nil, w, new(bytes.Buffer))
+	root := NewRoot(printer, createDefaultContext(), nil, utilsMock, nil, nil, _storage)
+
+	os.Args = []string{"globalping", "__complete", "ping", "jsdelivr.com", "from", ""}
+	err := root.Cmd.ExecuteContext(t.Context())
+	assert.NoError(t, err)
+	assert.Equal(t, `#baseline
+first
+last
+previous
+@1	ping jsdelivr.com
+`+measurementID1+`	ping jsdelivr.com
+:6
+`, w.String())
+}
+
+func Test_Completion_Share(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	utilsMock := utilsMocks.NewMockUtils(ctrl)
+	utilsMock.EXPECT().Now().Return(defaultCurrentTime).AnyTimes()
+
+	_storage := createDefaultTestStorage(t, utilsMock)
+	assert.NoError(t, _storage.SaveIdToSession(measurementID1))
+	assert.NoError(t, _storage.SaveCommandToHistory("1", defaultCurrentTime.Unix(), measurementID1, "ping jsdelivr.com"))
+
+	w := new(bytes.Buffer)
+	printer := view.NewPrinter(nil, w, new(bytes.Buffer))
+	root := NewRoot(printer, createDefaultContext(), nil, utilsMock, nil, nil, _storage)
+
+	os.Args = []string{"globalping", "__complete", "share", "@"}
+	err := root.Cmd.ExecuteContext(t.Context())
+	assert.NoError(t, err)
+	assert.Equal(t, "@1\tping jsdelivr.com\n:4\n", w.String())
+}
@@ -0,0 +1,489 @@
+package cmd
+
+import (
+	"errors"
+	"fmt"
+	"net/url"
+	"os"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/jsdelivr/globalping-cli/storage"
+	"github.com/jsdelivr/globalping-cli/utils"
+	"github.com/jsdelivr/globalping-cli/view"
+	"github.com/spf13/cobra"
+)
+
+var (
+	ErrUnknownSetting = errors.New("unknown setting")
+)
+
+type setting struct {
+	Key         string
+	Description string
+	// Applies the value to the config and context. Returns an error if the value is invalid.
+	Apply func(config *utils.Config, ctx *view.Context, value string) error
+}
+
+var outputFormats = []string{"default", "json", "latency", "table"}
+
+var settings = []*setting{
+	{
+		Key:         "from",
+		Description: "default probe locations",
+		Apply: func(_ *utils.Config, ctx *view.Context, value string) error {
+			ctx.From = value
+
+			return nil
+		},
+	},
+	{
+		Key:         "limit",
+		Description: "default number of probes to use",
+		Apply: func(_ *utils.Config, ctx *view.Context, value string) error {
+			limit, err := strconv.Atoi(value)
+
+			if err != nil || limit < 1 {
+				return errors.New("must be a number greater than 0")
+			}
+
+			ctx.Limit = limit
+
+			return nil
+		},
+	},
+	{
+		Key:         "ci",
+		Description: "disable real-time terminal updates and colors (true or false)",
+		Apply: func(_ *utils.Config, ctx *view.Context, value string) error {
+			ci, err := strconv.ParseBool(value)
+
+			if err != nil {
+				return errors.New("must be true or false")
+			}
+
+			ctx.CIMode = ci
+
+			return nil
+		},
+	},
+	{
+		Key:         "output",
+		Description: "default output format: " + strings.Join(outputFormats, ", "),
+		Apply: func(_ *utils.Config, ctx *view.Context, value string) error {
+			if !slices.Contains(outputFormats, value) {
+				return fmt.Errorf("must be one of: %s", strings.Join(outputFormats, ", "))
+			}
+
+			ctx.ToJSON = value == "json"
+			ctx.ToLatency = value == "latency"
+			ctx.Table = value == "table"
+
+			return nil
+		},
+	},
+	{
+		Key:         "method",
+		Description: "default HTTP method for http measurements: HEAD, GET, or OPTIONS",
+		Apply: func(_ *utils.Config, ctx *view.Context, value string) error {
+			method := strings.ToUpper(value)
+
+			if !slices.Contains([]string{"HEAD", "GET", "OPTIONS"}, method) {
+				return errors.New("must be one of: HEAD, GET, OPTIONS")
+			}
+
+			ctx.Method = method
+
+			return nil
+		},
+	},
+	{
+		Key:         "resolver",
+		Description: "default resolver for dns and http measurements",
+		Apply: func(_ *utils.Config, ctx *view.Context, value string) error {
+			ctx.Resolver = value
+
+			return nil
+		},
+	},
+	{
+		Key:         "on-complete",
+		Description: "shell command to run after each measurement which finished without failed tests",
+		Apply: func(_ *utils.Config, ctx *view.Context, value string) error {
+			ctx.OnComplete = value
+
+			return nil
+		},
+	},
+	{
+		Key:         "on-failure",
+		Description: "shell command to run after each measurement in which any test failed",
+		Apply: func(_ *utils.Config, ctx *view.Context, value string) error {
+			ctx.OnFailure = value
+
+			return nil
+		},
+	},
+	{
+		Key:         "api-interval",
+		Description: "interval between API requests while waiting for results, e.g. 500ms",
+		Apply: func(config *utils.Config, _ *view.Context, value string) error {
+			d, err := time.ParseDuration(value)
+
+			if err != nil || d <= 0 {
+				return errors.New("must be a positive duration, e.g. 500ms")
+			}
+
+			config.GlobalpingAPIInterval = d
+
+			return nil
+		},
+	},
+	{
+		Key:         "api-url",
+		Description: "base URL of the measurement API",
+		Apply: func(config *utils.Config, _ *view.Context, value string) error {
+			u, err := utils.ParseEndpointURL(value)
+
+			if err != nil {
+				return err
+			}
+
+			config.GlobalpingAPIURL = strings.TrimRight(u, "/")
+
+			return nil
+		},
+	},
+	{
+		Key:         "auth-url",
+		Description: "base URL of the auth server",
+		Apply: func(config *utils.Config, _ *view.Context, value string) error {
+			u, err := utils.ParseEndpointURL(value)
+
+			if err != nil {
+				return err
+			}
+
+			config.GlobalpingAuthURL = strings.TrimRight(u, "/")
+
+			return nil
+		},
+	},
+	{
+		Key:         "dashboard-url",
+		Description: "base URL of the dashboard",
+		Apply: func(config *utils.Config, _ *view.Context, value string) error {
+			u, err := utils.ParseEndpointURL(value)
+
+			if err != nil {
+				return err
+			}
+
+			config.GlobalpingDashboardURL = strings.TrimRight(u, "/")
+
+			return nil
+		},
+	},
+	{
+		Key:         "share-url",
+		Description: "URL prefix of shared measurement links; the measurement ID is appended to it",
+		Apply: func(config *utils.Config, _ *view.Context, value string) error {
+			u, err := utils.ParseEndpointURL(value)
+
+			if err != nil {
+				return err
+			}
+
+			config.GlobalpingShareURL = u
+
+			return nil
+		},
+	},
+	{
+		Key:         "proxy",
+		Description: "HTTP, HTTPS, or SOCKS5 proxy URL for API requests",
+		Apply: func(config *utils.Config, _ *view.Context, value string) error {
+			u, err := url.Parse(value)
+
+			if err != nil || !slices.Contains([]string{"http", "https", "socks5", "socks5h"}, u.Scheme) || u.Host == "" {
+				return errors.New("must be an http, https, or socks5 URL")
+			}
+
+			config.GlobalpingProxy = value
+
+			return nil
+		},
+	},
+	{
+		Key:         "ca-cert",
+		Description: "path to a PEM file with additional trusted CA certificates",
+		Apply: func(config *utils.Config, _ *view.Context, value string) error {
+			if err := checkFileExists(value); err != nil {
+				return err
+			}
+
+			config.GlobalpingCACert = value
+
+			return nil
+		},
+	},
+	{
+		Key:         "client-cert",
+		Description: "path to a PEM file with the client certificate for mutual TLS",
+		Apply: func(config *utils.Config, _ *view.Context, value string) error {
+			if err := checkFileExists(value); err != nil {
+				return err
+			}
+
+			config.GlobalpingClientCert = value
+
+			return nil
+		},
+	},
+	{
+		Key:         "client-key",
+		Description: "path to a PEM file with the key of the client certificate",
+		Apply: func(config *utils.Config, _ *view.Context, value string) error {
+			if err := checkFileExists(value); err != nil {
+				return err
+			}
+
+			config.GlobalpingClientKey = value
+
+			return nil
+		},
+	},
+	{
+		Key:         "retry",
+		Description: "number of retries on server and connection errors",
+		Apply: func(_ *utils.Config, ctx *view.Context, value string) error {
+			retry, err := strconv.Atoi(value)
+
+			if err != nil || retry < 0 {
+				return errors.New("must be a number greater than or equal to 0")
+			}
+
+			ctx.Retry = retry
+
+			return nil
+		},
+	},
+	{
+		Key:         "wait-for-credits",
+		Description: "wait for the rate limit to reset instead of failing (true or false)",
+		Apply: func(_ *utils.Config, ctx *view.Context, value string) error {
+			wait, err := strconv.ParseBool(value)
+
+			if err != nil {
+				return errors.New("must be true or false")
+			}
+
+			ctx.WaitForCredits = wait
+
+			return nil
+		},
+	},
+}
+
+func checkFileExists(path string) error {
+	info, err := os.Stat(path)
+
+	if err != nil || info.IsDir() {
+		return errors.New("must be a path to an existing file")
+	}
+
+	return nil
+}
+
+func findSetting(key string) *setting {
+	for _, s := range settings {
+		if s.Key == key {
+			return s
+		}
+	}
+
+	return nil
+}
+
+// Applies the stored settings to the config and context. Invalid settings are returned as an error and skipped.
+func applySettings(s storage.Settings, config *utils.Config, ctx *view.Context) error {
+	var errs []error
+
+	for _, def := range settings {
+		value, ok := s[def.Key]
+
+		if !ok {
+			continue
+		}
+
+		err := def.Apply(config, ctx, value)
+
+		if err != nil {
+			errs = append(errs, fmt.Errorf("invalid value %q for setting %s: %w", value, def.Key, err))
+		}
+	}
+
+	return errors.Join(errs...)
+}
+
+func (r *Root) initConfig() {
+	configCmd := &cobra.Command{
+		Use:   "config",
+		Short: "Manage the default settings",
+		Long: `Manage the default settings stored in the settings file. Command-line flags always take precedence over these settings.
+
+Available settings:
+` + settingsUsage() + `
+
+Examples:
+  # Use 3 probes in Europe by default.
+  config set from Europe
+  config set limit 3
+
+  # Output results in JSON format by default.
+  config set output json
+
+  # Show all settings.
+  config list`,
+	}
+
+	getCmd := &cobra.Command{
+		RunE:  r.RunConfigGet,
+		Use:   "get [key]",
+		Short: "Print the value of a setting",
+		Args:  cobra.ExactArgs(1),
+	}
+
+	setCmd := &cobra.Command{
+		RunE:  r.RunConfigSet,
+		Use:   "set [key] [value]",
+		Short: "Change the value of a setting",
+		Args:  cobra.ExactArgs(2),
+	}
+
+	unsetCmd := &cobra.Command{
+		RunE:  r.RunConfigUnset,
+		Use:   "unset [key]",
+		Short: "Remove a setting and restore its default value",
+		Args:  cobra.ExactArgs(1),
+	}
+
+	listCmd := &cobra.Command{
+		RunE:  r.RunConfigList,
+		Use:   "list",
+		Short: "List all settings that have a value",
+		Args:  cobra.NoArgs,
+	}
+
+	configCmd.AddCommand(getCmd)
+	configCmd.AddCommand(setCmd)
+	configCmd.AddCommand(unsetCmd)
+	configCmd.AddCommand(listCmd)
+
+	r.Cmd.AddCommand(configCmd)
+}
+
+func (r *Root) RunConfigGet(cmd *cobra.Command, args []string) error {
+	cmd.SilenceUsage = true
+
+	if findSetting(args[0]) == nil {
+		return fmt.Errorf("%w: %s", ErrUnknownSetting, args[0])
+	}
+
+	s, err := r.storage.LoadSettings()
+
+	if err != nil {
+		return err
+	}
+
+	if value, ok := s[args[0]]; ok {
+		r.printer.Println(value)
+	}
+
+	return nil
+}
+
+func (r *Root) RunConfigSet(cmd *cobra.Command, args []string) error {
+	cmd.SilenceUsage = true
+	def := findSetting(args[0])
+
+	if def == nil {
+		return fmt.Errorf("%w: %s", ErrUnknownSetting, args[0])
+	}
+
+	err := def.Apply(utils.NewConfig(), &view.Context{}, args[1])
+
+	if err != nil {
+		return fmt.Errorf("invalid value %q for setting %s: %w", args[1], args[0], err)
+	}
+
+	s, err := r.storage.LoadSettings()
+
+	if err != nil {
+		return err
+	}
+
+	s[args[0]] = args[1]
+
+	return r.storage.SaveSettings(s)
+}
+
+func (r *Root) RunConfigUnset(cmd *cobra.Command, args []string) error {
+	cmd.SilenceUsage = true
+
+	if findSetting(args[0]) == nil {
+		return fmt.Errorf("%w: %s", ErrUnknownSetting, args[0])
+	}
+
+	s, err := r.storage.LoadSettings()
+
+	if err != nil {
+		return err
+	}
+
+	delete(s, args[0])
+
+	return r.storage.SaveSettings(s)
+}
+
+func (r *Root) RunConfigList(cmd *cobra.Command, _ []string) error {
+	cmd.SilenceUsage = true
+	s, err := r.storage.LoadSettings()
+
+	if err != nil {
+		return err
+	}
+
+	found := false
+
+	for _, def := range settings {
+		if value, ok := s[def.Key]; ok {
+			r.printer.Printf("%s: %s\n", def.Key, value)
+			found = true
+		}
+	}
+
+	if !found {
+		r.printer.Println("No settings found")
+	}
+
+	return nil
+}
+
+func settingsUsage() string {
+	width := 0
+
+	for _, s := range settings {
+		width = max(width, len(s.Key))
+	}
+
+	lines := make([]string, len(settings))
+
+	for i, s := range settings {
+		lines[i] = fmt.Sprintf("  %-*s  %s", width, s.Key, s.Description)
+	}
+
+	return strings.Join(lines, "\n")
+}
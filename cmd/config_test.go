@@ -0,0 +1,159 @@
+package cmd
+
+import (
+	"bytes"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/jsdelivr/globalping-cli/storage"
+	"github.com/jsdelivr/globalping-cli/utils"
+	"github.com/jsdelivr/globalping-cli/view"
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_Config_SetGetList(t *testing.T) {
+	w := new(bytes.Buffer)
+	printer := view.NewPrinter(nil, w, w)
+	ctx := createDefaultContext()
+	_storage := createDefaultTestStorage(t, nil)
+
+	root := NewRoot(printer, ctx, nil, nil, nil, nil, _storage)
+
+	os.Args = []string{"globalping", "config", "list"}
+	err := root.Cmd.ExecuteContext(t.Context())
+	assert.NoError(t, err)
+	assert.Equal(t, "No settings found\n", w.String())
+
+	os.Args = []string{"globalping", "config", "set", "from", "Europe"}
+	err = root.Cmd.ExecuteContext(t.Context())
+	assert.NoError(t, err)
+
+	os.Args = []string{"globalping", "config", "set", "output", "json"}
+	err = root.Cmd.ExecuteContext(t.Context())
+	assert.NoError(t, err)
+
+	os.Args = []string{"globalping", "config", "set", "limit", "0"}
+	err = root.Cmd.ExecuteContext(t.Context())
+	assert.EqualError(t, err, `invalid value "0" for setting limit: must be a number greater than 0`)
+
+	os.Args = []string{"globalping", "config", "set", "unknown", "value"}
+	err = root.Cmd.ExecuteContext(t.Context())
+	assert.ErrorIs(t, err, ErrUnknownSetting)
+
+	w.Reset()
+	os.Args = []string{"globalping", "config", "get", "from"}
+	err = root.Cmd.ExecuteContext(t.Context())
+	assert.NoError(t, err)
+	assert.Equal(t, "Europe\n", w.String())
+
+	w.Reset()
+	os.Args = []string{"globalping", "config", "list"}
+	err = root.Cmd.ExecuteContext(t.Context())
+	assert.NoError(t, err)
+	assert.Equal(t, "from: Europe\noutput: json\n", w.String())
+
+	os.Args = []string{"globalping", "config", "unset", "output"}
+	err = root.Cmd.ExecuteContext(t.Context())
+	assert.NoError(t, err)
+
+	s, err := _storage.LoadSettings()
+	assert.NoError(t, err)
+	assert.Equal(t, storage.Settings{"from": "Europe"}, s)
+}
+
+func Test_ApplySettings(t *testing.T) {
+	config := utils.NewConfig()
+	ctx := &view.Context{From: "world", Limit: 1}
+
+	err := applySettings(storage.Settings{
+		"from":         "Europe",
+		"limit":        "3",
+		"ci":           "true",
+		"output":       "table",
+		"method":       "get",
+		"resolver":     "1.1.1.1",
+		"api-interval": "1s",
+	}, config, ctx)
+	assert.NoError(t, err)
+
+	assert.Equal(t, &view.Context{
+		From:     "Europe",
+		Limit:    3,
+		CIMode:   true,
+		Table:    true,
+		Method:   "GET",
+		Resolver: "1.1.1.1",
+	}, ctx)
+	assert.Equal(t, time.Second, config.GlobalpingAPIInterval)
+
+	ctx = &view.Context{From: "world", Limit: 1}
+	err = applySettings(storage.Settings{
+		"from":  "Europe",
+		"limit": "x",
+	}, config, ctx)
+	assert.EqualError(t, err, `invalid value "x" for setting limit: must be a number greater than 0`)
+	assert.Equal(t, &view.Context{From: "Europe", Limit: 1}, ctx)
+}
+
+func Test_ApplySettings_Endpoints(t *testing.T) {
+	config := utils.NewConfig()
+	ctx := &view.Context{}
+
+	err := applySettings(storage.Settings{
+		"api-url":       "https://api.staging.globalping.io/v1/",
+		"auth-url":      "http://localhost:13000",
+		"dashboard-url": "https://dash.staging.globalping.io/",
+		"share-url":     "https://staging.globalping.io?measurement=",
+	}, config, ctx)
+	assert.NoError(t, err)
+
+	assert.Equal(t, "https://api.staging.globalping.io/v1", config.GlobalpingAPIURL)
+	assert.Equal(t, "http://localhost:13000", config.GlobalpingAuthURL)
+	assert.Equal(t, "https://dash.staging.globalping.io", config.GlobalpingDashboardURL)
+	assert.Equal(t, "https://staging.globalping.io?measurement=", config.GlobalpingShareURL)
+
+	config = utils.NewConfig()
+	err = applySettings(storage.Settings{"api-url": "api.staging.globalping.io"}, config, ctx)
+	assert.EqualError(t, err, `invalid value "api.staging.globalping.io" for setting api-url: must be an absolute http or https URL`)
+	assert.Equal(t, "", config.GlobalpingAPIURL)
+}
+
+func Test_Config_Load_Endpoints(t *testing.T) {
+	t.Setenv("GLOBALPING_API_URL", "https://api.staging.globalping.io/v1/")
+	t.Setenv("GLOBALPING_AUTH_URL", "http://localhost:13000")
+
+	config := utils.NewConfig()
+	assert.NoError(t, config.Load())
+	assert.Equal(t, "https://api.staging.globalping.io/v1", config.GlobalpingAPIURL)
+	assert.Equal(t, "http://localhost:13000", config.GlobalpingAuthURL)
+
+	// The variables are validated in the same way as the settings
+	t.Setenv("GLOBALPING_AUTH_URL", "localhost:13000")
+
+	config = utils.NewConfig()
+	assert.EqualError(t, config.Load(), "invalid GLOBALPING_AUTH_URL: must be an absolute http or https URL")
+	assert.Equal(t, "", config.GlobalpingAuthURL)
+}
+
+func Test_ParseClientFlags(t *testing.T) {
+	config := utils.NewConfig()
+	config.GlobalpingProxy = "http://env-proxy:8080"
+	config.GlobalpingCACert = "/etc/ssl/env.pem"
+
+	parseClientFlags([]string{"ping", "google.com", "--proxy", "socks5://localhost:1080", "-L", "2", "--client-cert=client.pem"}, config)
+	assert.Equal(t, "socks5://localhost:1080", config.GlobalpingProxy)
+	assert.Equal(t, "/etc/ssl/env.pem", config.GlobalpingCACert)
+	assert.Equal(t, "client.pem", config.GlobalpingClientCert)
+	assert.Equal(t, "", config.GlobalpingClientKey)
+	assert.False(t, config.GlobalpingDebug)
+
+	parseClientFlags([]string{"auth", "login", "--debug", "--debug-file=debug.log"}, config)
+	assert.True(t, config.GlobalpingDebug)
+	assert.False(t, config.GlobalpingDebugBodies)
+	assert.Equal(t, "debug.log", config.GlobalpingDebugFile)
+
+	parseClientFlags([]string{"ping", "google.com", "--infinite", "--replay", "recordings/ping"}, config)
+	assert.Equal(t, "recordings/ping", config.GlobalpingReplayDir)
+	assert.Equal(t, "", config.GlobalpingRecordDir)
+}
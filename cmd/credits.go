@@ -0,0 +1,80 @@
+package cmd
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/jsdelivr/globalping-go"
+)
+
+var (
+	ErrCreditBudgetExceeded = errors.New("credit budget exceeded")
+	ErrNotEnoughCredits     = errors.New("not enough credits")
+)
+
+// Returns an error if creating the measurement would exceed the --max-credits budget or the available credits.
+// The available credits are only checked before the first measurement.
+func (r *Root) checkCreditBudget(ctx context.Context, opts *globalping.MeasurementCreate) error {
+	if r.ctx.MaxCredits <= 0 {
+		return nil
+	}
+
+	cost := estimateCredits(opts)
+
+	if r.ctx.CreditsSpent+cost > r.ctx.MaxCredits {
+		return fmt.Errorf("%w: %d of %d credits spent, and the next measurement would use %d more", ErrCreditBudgetExceeded, r.ctx.CreditsSpent, r.ctx.MaxCredits, cost)
+	}
+
+	if r.ctx.MeasurementsCreated > 0 || r.ctx.WaitForCredits {
+		return nil
+	}
+
+	limits, err := r.client.Limits(ctx)
+
+	if err != nil {
+		return nil // The API reports the rate limit when the measurement is created
+	}
+
+	available := limits.RateLimits.Measurements.Create.Remaining + limits.Credits.Remaining
+
+	if cost > available {
+		return fmt.Errorf("%w: the measurement would use %d credits, but only %d are available", ErrNotEnoughCredits, cost, available)
+	}
+
+	return nil
+}
+
+// Returns the estimated number of credits used by the measurement.
+func estimateCredits(opts *globalping.MeasurementCreate) int64 {
+	return creditsPerProbe(opts) * estimateProbes(opts)
+}
+
+// Returns the number of credits used by each probe. Ping uses 1 credit for every 16 packets, other measurements use 1 credit.
+func creditsPerProbe(opts *globalping.MeasurementCreate) int64 {
+	if opts.Type == "ping" && opts.Options != nil && opts.Options.Packets > 16 {
+		return int64((opts.Options.Packets + 15) / 16)
+	}
+
+	return 1
+}
+
+func estimateProbes(opts *globalping.MeasurementCreate) int64 {
+	if locations, ok := opts.Locations.(globalping.LocationOptions); ok {
+		probes := 0
+
+		for _, l := range locations {
+			probes += l.Limit
+		}
+
+		if probes > 0 {
+			return int64(probes)
+		}
+	}
+
+	if opts.Limit > 0 {
+		return int64(opts.Limit)
+	}
+
+	return 1
+}
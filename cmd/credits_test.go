@@ -0,0 +1,139 @@
+package cmd
+
+import (
+	"bytes"
+	"os"
+	"testing"
+	"time"
+
+	apiMocks "github.com/jsdelivr/globalping-cli/mocks/api"
+	utilsMocks "github.com/jsdelivr/globalping-cli/mocks/utils"
+	viewMocks "github.com/jsdelivr/globalping-cli/mocks/view"
+	"github.com/jsdelivr/globalping-cli/view"
+	"github.com/jsdelivr/globalping-go"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/mock/gomock"
+)
+
+func Test_Execute_Ping_MaxCredits_NotEnoughCredits(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	gbMock := apiMocks.NewMockClient(ctrl)
+	gbMock.EXPECT().Limits(gomock.Any()).Return(createLimitsResponse(2, 1), nil)
+	gbMock.EXPECT().CreateMeasurement(gomock.Any(), gomock.Any()).Times(0)
+
+	viewerMock := viewMocks.NewMockViewer(ctrl)
+
+	utilsMock := utilsMocks.NewMockUtils(ctrl)
+	utilsMock.EXPECT().Now().Return(defaultCurrentTime).AnyTimes()
+
+	w := new(bytes.Buffer)
+	printer := view.NewPrinter(nil, w, w)
+	ctx := createDefaultContext()
+	_storage := createDefaultTestStorage(t, utilsMock)
+	root := NewRoot(printer, ctx, viewerMock, utilsMock, gbMock, nil, _storage)
+
+	os.Args = []string{"globalping", "ping", "jsdelivr.com", "--limit", "5", "--max-credits", "10"}
+	err := root.Cmd.ExecuteContext(t.Context())
+	assert.EqualError(t, err, "not enough credits: the measurement would use 5 credits, but only 3 are available")
+	assert.Equal(t, "Error: not enough credits: the measurement would use 5 credits, but only 3 are available\n", w.String())
+}
+
+func Test_Execute_Ping_MaxCredits_Exceeded(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	gbMock := apiMocks.NewMockClient(ctrl)
+	gbMock.EXPECT().CreateMeasurement(gomock.Any(), gomock.Any()).Times(0)
+
+	viewerMock := viewMocks.NewMockViewer(ctrl)
+
+	utilsMock := utilsMocks.NewMockUtils(ctrl)
+	utilsMock.EXPECT().Now().Return(defaultCurrentTime).AnyTimes()
+
+	w := new(bytes.Buffer)
+	printer := view.NewPrinter(nil, w, w)
+	ctx := createDefaultContext()
+	_storage := createDefaultTestStorage(t, utilsMock)
+	root := NewRoot(printer, ctx, viewerMock, utilsMock, gbMock, nil, _storage)
+
+	os.Args = []string{"globalping", "ping", "jsdelivr.com", "--limit", "5", "--max-credits", "4"}
+	err := root.Cmd.ExecuteContext(t.Context())
+	assert.EqualError(t, err, "credit budget exceeded: 0 of 4 credits spent, and the next measurement would use 5 more")
+}
+
+func Test_Execute_Ping_Infinite_MaxCredits(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	expectedOpts := createDefaultMeasurementCreate("ping")
+	expectedOpts.Options.Packets = 16
+	expectedOpts.InProgressUpdates = true
+
+	gbMock := apiMocks.NewMockClient(ctrl)
+	gbMock.EXPECT().Limits(gomock.Any()).Return(createLimitsResponse(100, 0), nil)
+	gbMock.EXPECT().CreateMeasurement(gomock.Any(), expectedOpts).Times(1).Return(createDefaultMeasurementCreateResponse(), nil)
+
+	expectedMeasurement := createDefaultMeasurement("ping")
+	gbMock.EXPECT().GetMeasurement(gomock.Any(), measurementID1).Return(expectedMeasurement, nil)
+
+	viewerMock := viewMocks.NewMockViewer(ctrl)
+	waitFn := func(_ *globalping.Measurement) (string, error) { time.Sleep(5 * time.Millisecond); return "", nil }
+	viewerMock.EXPECT().OutputInfinite(expectedMeasurement).DoAndReturn(waitFn)
+	viewerMock.EXPECT().OutputSummary(gomock.Any()).Times(1)
+	viewerMock.EXPECT().OutputShare().Times(1)
+
+	utilsMock := utilsMocks.NewMockUtils(ctrl)
+	utilsMock.EXPECT().Now().Return(defaultCurrentTime).AnyTimes()
+
+	w := new(bytes.Buffer)
+	errW := new(bytes.Buffer)
+	printer := view.NewPrinter(nil, w, errW)
+	ctx := createDefaultContext()
+	_storage := createDefaultTestStorage(t, utilsMock)
+	root := NewRoot(printer, ctx, viewerMock, utilsMock, gbMock, nil, _storage)
+
+	os.Args = []string{"globalping", "ping", "jsdelivr.com", "from", "Berlin", "--infinite", "--max-credits", "1"}
+	err := root.Cmd.ExecuteContext(t.Context())
+	assert.NoError(t, err)
+
+	assert.Equal(t, "Stopped after spending 1 of 1 credits.\n", errW.String())
+	assert.Equal(t, int64(1), ctx.CreditsSpent)
+}
+
+func Test_EstimateCredits(t *testing.T) {
+	assert.Equal(t, int64(1), estimateCredits(&globalping.MeasurementCreate{Type: "dns"}))
+	assert.Equal(t, int64(3), estimateCredits(&globalping.MeasurementCreate{Type: "dns", Limit: 3}))
+	assert.Equal(t, int64(5), estimateCredits(&globalping.MeasurementCreate{
+		Type:      "traceroute",
+		Limit:     1,
+		Locations: globalping.LocationOptions{{Magic: "Berlin", Limit: 2}, {Magic: "Paris", Limit: 3}},
+	}))
+	assert.Equal(t, int64(2), estimateCredits(&globalping.MeasurementCreate{
+		Type:    "ping",
+		Limit:   2,
+		Options: &globalping.MeasurementOptions{Packets: 16},
+	}))
+	assert.Equal(t, int64(4), estimateCredits(&globalping.MeasurementCreate{
+		Type:    "ping",
+		Limit:   2,
+		Options: &globalping.MeasurementOptions{Packets: 17},
+	}))
+}
+
+func createLimitsResponse(remaining int64, credits int64) *globalping.LimitsResponse {
+	return &globalping.LimitsResponse{
+		RateLimits: globalping.RateLimits{
+			Measurements: globalping.MeasurementsLimits{
+				Create: globalping.MeasurementsCreateLimits{
+					Type:      globalping.CreateLimitTypeUser,
+					Limit:     500,
+					Remaining: remaining,
+					Reset:     600,
+				},
+			},
+		},
+		Credits: globalping.CreditLimits{Remaining: credits},
+	}
+}
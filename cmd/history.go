@@ -69,6 +69,11 @@ func (r *Root) UpdateHistory() error {
 		return nil
 	}
 
+	return r.saveToHistory(ids, strings.Join(os.Args[1:], " "))
+}
+
+// Saves the measurements created by the command to the history of the session.
+func (r *Root) saveToHistory(ids string, command string) error {
 	index := "-"
 
 	if !r.ctx.IsLocationFromSession {
@@ -85,7 +90,7 @@ func (r *Root) UpdateHistory() error {
 		index,
 		r.utils.Now().Unix(),
 		ids,
-		strings.Join(os.Args[1:], " "),
+		command,
 	)
 
 	if err != nil {
@@ -0,0 +1,174 @@
+package cmd
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"os"
+
+	"github.com/jsdelivr/globalping-cli/utils"
+	"github.com/jsdelivr/globalping-go"
+)
+
+// The number of hooks of continuous measurements which can wait for the previous ones to finish before the polling is blocked
+var hookQueueSize = 100
+
+// Runs the --on-complete or the --on-failure command for the finished measurement, and writes the output of the command to out.
+// Hook errors are written as warnings, so that they don't change the result of the measurement command.
+func (r *Root) runMeasurementHook(ctx context.Context, m *globalping.Measurement, out io.Writer) {
+	name, command, status := "on-complete", r.ctx.OnComplete, string(m.Status)
+
+	if hasFailedTests(m) {
+		name, command, status = "on-failure", r.ctx.OnFailure, "failed"
+	}
+
+	if command == "" {
+		return
+	}
+
+	// The raw results are saved to a file, as they may be too large for an environment variable
+	b, err := r.client.GetMeasurementRaw(ctx, m.ID)
+
+	if err != nil {
+		_, _ = fmt.Fprintf(out, "Warning: failed to run the %s hook: %v\n", name, err)
+
+		return
+	}
+
+	path, err := writeHookFile(m.ID, b)
+
+	if err != nil {
+		_, _ = fmt.Fprintf(out, "Warning: failed to run the %s hook: %v\n", name, err)
+
+		return
+	}
+
+	defer func() {
+		_ = os.Remove(path)
+	}()
+
+	env := hookEnv(m.ID, string(m.Type), m.Target, status)
+	r.runHook(name, command, append(env, "GLOBALPING_MEASUREMENT_FILE="+path), out)
+}
+
+// Runs the --on-failure command for a measurement which was created, but whose results could not be retrieved.
+// The error is passed instead of the results file.
+func (r *Root) runMeasurementErrorHook(id string, opts *globalping.MeasurementCreate, measurementErr error, out io.Writer) {
+	if r.ctx.OnFailure == "" {
+		return
+	}
+
+	env := hookEnv(id, string(opts.Type), opts.Target, "failed")
+	r.runHook("on-failure", r.ctx.OnFailure, append(env, "GLOBALPING_MEASUREMENT_ERROR="+measurementErr.Error()), out)
+}
+
+func (r *Root) runHook(name string, command string, env []string, out io.Writer) {
+	err := r.utils.RunCommand(command, env, out)
+
+	if err != nil {
+		_, _ = fmt.Fprintf(out, "Warning: the %s hook failed: %v\n", name, err)
+	}
+}
+
+func hookEnv(id string, measurementType string, target string, status string) []string {
+	return []string{
+		"GLOBALPING_MEASUREMENT_ID=" + id,
+		"GLOBALPING_MEASUREMENT_TYPE=" + measurementType,
+		"GLOBALPING_MEASUREMENT_TARGET=" + target,
+		"GLOBALPING_MEASUREMENT_STATUS=" + status,
+		"GLOBALPING_MEASUREMENT_URL=" + utils.ShareURL + id,
+	}
+}
+
+// Returns true if any test of the measurement failed or the probe went offline.
+func hasFailedTests(m *globalping.Measurement) bool {
+	for i := range m.Results {
+		status := m.Results[i].Result.Status
+
+		if status == globalping.TestStatusFailed || status == globalping.TestStatusOffline {
+			return true
+		}
+	}
+
+	return false
+}
+
+func writeHookFile(id string, b []byte) (string, error) {
+	f, err := os.CreateTemp("", "globalping-"+id+"-*.json")
+
+	if err != nil {
+		return "", err
+	}
+
+	_, err = f.Write(b)
+
+	if closeErr := f.Close(); err == nil {
+		err = closeErr
+	}
+
+	if err != nil {
+		_ = os.Remove(f.Name())
+
+		return "", err
+	}
+
+	return f.Name(), nil
+}
+
+// Runs the hooks of continuous measurements in the background, so that a slow hook doesn't delay the polling.
+// The output is buffered until the live output is finished, as it would break the live table otherwise.
+type hookQueue struct {
+	measurements chan *globalping.Measurement
+	pending      []*globalping.Measurement
+	out          bytes.Buffer
+	done         chan struct{}
+}
+
+func (r *Root) startHookQueue(ctx context.Context) *hookQueue {
+	q := &hookQueue{
+		measurements: make(chan *globalping.Measurement, hookQueueSize),
+		done:         make(chan struct{}),
+	}
+
+	go func() {
+		defer close(q.done)
+
+		for m := range q.measurements {
+			r.runMeasurementHook(ctx, m, &q.out)
+		}
+
+		// Added after the run was stopped, once the channel is closed
+		for _, m := range q.pending {
+			r.runMeasurementHook(ctx, m, &q.out)
+		}
+	}()
+
+	return q
+}
+
+// Queues the hook of the finished measurement. If too many hooks are already waiting, blocks until there is room,
+// so that the polling slows down to the pace of the hooks. If ctx is canceled meanwhile, the hook runs once the queue is finished.
+func (q *hookQueue) add(ctx context.Context, m *globalping.Measurement) {
+	select {
+	case q.measurements <- m:
+	case <-ctx.Done():
+		q.pending = append(q.pending, m)
+	}
+}
+
+// Waits for the queued hooks to finish and writes their output to w. Stops waiting if cancel receives a signal.
+// Must not be called concurrently with add.
+func (q *hookQueue) finish(cancel <-chan os.Signal, w io.Writer) {
+	close(q.measurements)
+
+	select {
+	case <-q.done:
+	case <-cancel:
+		_, _ = fmt.Fprintln(w, "Warning: stopped waiting for the hooks to finish")
+
+		return
+	}
+
+	_, _ = w.Write(q.out.Bytes())
+}
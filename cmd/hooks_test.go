@@ -0,0 +1,277 @@
+package cmd
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"io"
+	"os"
+	"strings"
+	"syscall"
+	"testing"
+	"time"
+
+	apiMocks "github.com/jsdelivr/globalping-cli/mocks/api"
+	utilsMocks "github.com/jsdelivr/globalping-cli/mocks/utils"
+	viewMocks "github.com/jsdelivr/globalping-cli/mocks/view"
+	"github.com/jsdelivr/globalping-cli/view"
+	"github.com/jsdelivr/globalping-go"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/mock/gomock"
+)
+
+func Test_Execute_Hook_OnComplete(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	expectedOpts := createDefaultMeasurementCreate("ping")
+	expectedResponse := createDefaultMeasurementCreateResponse()
+	expectedMeasurement := createDefaultMeasurement("ping")
+	expectedMeasurement.Target = "jsdelivr.com"
+	raw := []byte(`{"id":"` + measurementID1 + `"}`)
+
+	gbMock := apiMocks.NewMockClient(ctrl)
+	gbMock.EXPECT().CreateMeasurement(gomock.Any(), expectedOpts).Return(expectedResponse, nil)
+	gbMock.EXPECT().AwaitMeasurement(gomock.Any(), measurementID1).Return(expectedMeasurement, nil)
+	gbMock.EXPECT().GetMeasurementRaw(gomock.Any(), measurementID1).Return(raw, nil)
+
+	viewerMock := viewMocks.NewMockViewer(ctrl)
+	viewerMock.EXPECT().OutputDefault(measurementID1, expectedMeasurement, expectedOpts)
+
+	w := new(bytes.Buffer)
+	var hookFile string
+
+	utilsMock := utilsMocks.NewMockUtils(ctrl)
+	utilsMock.EXPECT().Now().Return(defaultCurrentTime).AnyTimes()
+	utilsMock.EXPECT().RunCommand("notify --ok", gomock.Any(), w).DoAndReturn(func(_ string, env []string, out io.Writer) error {
+		hookFile = strings.TrimPrefix(env[5], "GLOBALPING_MEASUREMENT_FILE=")
+		assert.Equal(t, []string{
+			"GLOBALPING_MEASUREMENT_ID=" + measurementID1,
+			"GLOBALPING_MEASUREMENT_TYPE=ping",
+			"GLOBALPING_MEASUREMENT_TARGET=jsdelivr.com",
+			"GLOBALPING_MEASUREMENT_STATUS=finished",
+			"GLOBALPING_MEASUREMENT_URL=https://globalping.io?measurement=" + measurementID1,
+			"GLOBALPING_MEASUREMENT_FILE=" + hookFile,
+		}, env)
+
+		b, err := os.ReadFile(hookFile)
+		assert.NoError(t, err)
+		assert.Equal(t, raw, b)
+
+		_, err = out.Write([]byte("Sent.\n"))
+
+		return err
+	})
+
+	printer := view.NewPrinter(nil, w, w)
+	ctx := createDefaultContext()
+	root := NewRoot(printer, ctx, viewerMock, utilsMock, gbMock, nil, createDefaultTestStorage(t, utilsMock))
+
+	os.Args = []string{"globalping", "ping", "jsdelivr.com", "from", "Berlin", "--on-complete", "notify --ok", "--on-failure", "notify --failed"}
+	err := root.Cmd.ExecuteContext(t.Context())
+	assert.NoError(t, err)
+
+	assert.Equal(t, "Sent.\n", w.String())
+	assert.Equal(t, "notify --ok", ctx.OnComplete)
+
+	_, err = os.Stat(hookFile)
+	assert.True(t, os.IsNotExist(err))
+}
+
+func Test_Execute_Hook_OnFailure(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	expectedOpts := createDefaultMeasurementCreate("ping")
+	expectedResponse := createDefaultMeasurementCreateResponse()
+	expectedMeasurement := createDefaultMeasurement("ping")
+	expectedMeasurement.Results[0].Result.Status = globalping.TestStatusFailed
+
+	gbMock := apiMocks.NewMockClient(ctrl)
+	gbMock.EXPECT().CreateMeasurement(gomock.Any(), expectedOpts).Return(expectedResponse, nil)
+	gbMock.EXPECT().AwaitMeasurement(gomock.Any(), measurementID1).Return(expectedMeasurement, nil)
+	gbMock.EXPECT().GetMeasurementRaw(gomock.Any(), measurementID1).Return([]byte(`{}`), nil)
+
+	viewerMock := viewMocks.NewMockViewer(ctrl)
+	viewerMock.EXPECT().OutputDefault(measurementID1, expectedMeasurement, expectedOpts)
+
+	w := new(bytes.Buffer)
+
+	utilsMock := utilsMocks.NewMockUtils(ctrl)
+	utilsMock.EXPECT().Now().Return(defaultCurrentTime).AnyTimes()
+	utilsMock.EXPECT().RunCommand("notify --failed", gomock.Any(), w).DoAndReturn(func(_ string, env []string, _ io.Writer) error {
+		assert.Equal(t, "GLOBALPING_MEASUREMENT_STATUS=failed", env[3])
+
+		return errors.New("exit status 1")
+	})
+
+	printer := view.NewPrinter(nil, w, w)
+	ctx := createDefaultContext()
+	ctx.OnComplete = "notify --ok"
+	ctx.OnFailure = "notify --failed"
+	root := NewRoot(printer, ctx, viewerMock, utilsMock, gbMock, nil, createDefaultTestStorage(t, utilsMock))
+
+	os.Args = []string{"globalping", "ping", "jsdelivr.com", "from", "Berlin"}
+	err := root.Cmd.ExecuteContext(t.Context())
+	assert.NoError(t, err)
+
+	assert.Equal(t, "Warning: the on-failure hook failed: exit status 1\n", w.String())
+}
+
+func Test_Execute_Hook_Ping_Infinite(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	expectedOpts := createDefaultMeasurementCreate("ping")
+	expectedOpts.Options.Packets = 16
+	expectedOpts.InProgressUpdates = true
+	expectedResponse2 := createDefaultMeasurementCreateResponse()
+	expectedResponse2.ID = measurementID2
+
+	expectedMeasurement1 := createDefaultMeasurement("ping")
+	expectedMeasurement2 := createDefaultMeasurement_MultipleProbes(globalping.MeasurementStatusInProgress, globalping.TestStatusInProgress)
+	expectedMeasurement2.ID = measurementID2
+
+	gbMock := apiMocks.NewMockClient(ctrl)
+	gbMock.EXPECT().CreateMeasurement(gomock.Any(), expectedOpts).Return(createDefaultMeasurementCreateResponse(), nil)
+	gbMock.EXPECT().CreateMeasurement(gomock.Any(), gomock.Any()).Return(expectedResponse2, nil).AnyTimes()
+	gbMock.EXPECT().GetMeasurement(gomock.Any(), measurementID1).Return(expectedMeasurement1, nil)
+	gbMock.EXPECT().GetMeasurement(gomock.Any(), measurementID2).Return(expectedMeasurement2, nil).AnyTimes()
+	gbMock.EXPECT().GetMeasurementRaw(gomock.Any(), measurementID1).Return([]byte(`{}`), nil)
+
+	viewerMock := viewMocks.NewMockViewer(ctrl)
+	viewerMock.EXPECT().OutputInfinite(gomock.Any()).Return("", nil).AnyTimes()
+	viewerMock.EXPECT().OutputSummary("")
+	viewerMock.EXPECT().OutputShare()
+
+	w := new(bytes.Buffer)
+	printer := view.NewPrinter(nil, w, w)
+	ctx := &view.Context{
+		History: view.NewHistoryBuffer(10),
+		From:    "world",
+		Limit:   1,
+	}
+
+	utilsMock := utilsMocks.NewMockUtils(ctrl)
+	root := NewRoot(printer, ctx, viewerMock, utilsMock, gbMock, nil, createDefaultTestStorage(t, utilsMock))
+
+	utilsMock.EXPECT().Now().Return(defaultCurrentTime).AnyTimes()
+	// The output is buffered until the live table is finished
+	utilsMock.EXPECT().RunCommand("notify", gomock.Any(), gomock.Any()).DoAndReturn(func(_ string, env []string, out io.Writer) error {
+		assert.Equal(t, "GLOBALPING_MEASUREMENT_ID="+measurementID1, env[0])
+		assert.NotSame(t, w, out)
+		_, err := out.Write([]byte("Sent.\n"))
+		root.cancel <- syscall.SIGINT
+
+		return err
+	})
+
+	os.Args = []string{"globalping", "ping", "jsdelivr.com", "--infinite", "from", "Berlin", "--on-complete", "notify"}
+	err := root.Cmd.ExecuteContext(t.Context())
+	assert.NoError(t, err)
+
+	assert.Equal(t, "Sent.\n", w.String())
+}
+
+func Test_Execute_Hook_OnFailure_Error(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	expectedOpts := createDefaultMeasurementCreate("ping")
+	expectedResponse := createDefaultMeasurementCreateResponse()
+	awaitErr := errors.New("connection reset by peer")
+
+	gbMock := apiMocks.NewMockClient(ctrl)
+	gbMock.EXPECT().CreateMeasurement(gomock.Any(), expectedOpts).Return(expectedResponse, nil)
+	gbMock.EXPECT().AwaitMeasurement(gomock.Any(), measurementID1).Return(nil, awaitErr)
+
+	viewerMock := viewMocks.NewMockViewer(ctrl)
+
+	w := new(bytes.Buffer)
+
+	utilsMock := utilsMocks.NewMockUtils(ctrl)
+	utilsMock.EXPECT().Now().Return(defaultCurrentTime).AnyTimes()
+	utilsMock.EXPECT().RunCommand("notify --failed", []string{
+		"GLOBALPING_MEASUREMENT_ID=" + measurementID1,
+		"GLOBALPING_MEASUREMENT_TYPE=ping",
+		"GLOBALPING_MEASUREMENT_TARGET=jsdelivr.com",
+		"GLOBALPING_MEASUREMENT_STATUS=failed",
+		"GLOBALPING_MEASUREMENT_URL=https://globalping.io?measurement=" + measurementID1,
+		"GLOBALPING_MEASUREMENT_ERROR=connection reset by peer",
+	}, w).Return(nil)
+
+	printer := view.NewPrinter(nil, w, w)
+	ctx := createDefaultContext()
+	ctx.OnFailure = "notify --failed"
+	root := NewRoot(printer, ctx, viewerMock, utilsMock, gbMock, nil, createDefaultTestStorage(t, utilsMock))
+
+	os.Args = []string{"globalping", "ping", "jsdelivr.com", "from", "Berlin"}
+	err := root.Cmd.ExecuteContext(t.Context())
+	assert.ErrorIs(t, err, awaitErr)
+}
+
+func Test_HookQueue_Backpressure(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	defaultQueueSize := hookQueueSize
+	hookQueueSize = 1
+	t.Cleanup(func() { hookQueueSize = defaultQueueSize })
+
+	gbMock := apiMocks.NewMockClient(ctrl)
+	gbMock.EXPECT().GetMeasurementRaw(gomock.Any(), gomock.Any()).Return([]byte(`{}`), nil).Times(4)
+
+	release := make(chan struct{})
+	var ids []string
+	utilsMock := utilsMocks.NewMockUtils(ctrl)
+	utilsMock.EXPECT().RunCommand("notify", gomock.Any(), gomock.Any()).DoAndReturn(func(_ string, env []string, _ io.Writer) error {
+		<-release
+		ids = append(ids, strings.TrimPrefix(env[0], "GLOBALPING_MEASUREMENT_ID="))
+
+		return nil
+	}).Times(4)
+
+	w := new(bytes.Buffer)
+	printer := view.NewPrinter(nil, w, w)
+	ctx := createDefaultContext()
+	ctx.OnComplete = "notify"
+	root := NewRoot(printer, ctx, nil, utilsMock, gbMock, nil, nil)
+
+	measurement := func(id string) *globalping.Measurement {
+		m := createDefaultMeasurement("ping")
+		m.ID = id
+
+		return m
+	}
+
+	q := root.startHookQueue(t.Context())
+	q.add(t.Context(), measurement("1"))
+	q.add(t.Context(), measurement("2"))
+
+	// The first hook is running and the second one fills the queue, so the third one has to wait
+	added := make(chan struct{})
+	go func() {
+		q.add(t.Context(), measurement("3"))
+		close(added)
+	}()
+
+	select {
+	case <-added:
+		t.Fatal("the hook was added to a full queue")
+	case <-time.After(50 * time.Millisecond):
+	}
+
+	release <- struct{}{}
+	<-added
+
+	// Hooks added after the run was stopped still run
+	canceledCtx, cancel := context.WithCancel(t.Context())
+	cancel()
+	q.add(canceledCtx, measurement("4"))
+
+	close(release)
+	q.finish(nil, w)
+
+	assert.Equal(t, []string{"1", "2", "3", "4"}, ids)
+	assert.Equal(t, "", w.String())
+}
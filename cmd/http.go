@@ -8,7 +8,6 @@ import (
 	"strconv"
 	"strings"
 
-	"github.com/jsdelivr/globalping-cli/view"
 	"github.com/jsdelivr/globalping-go"
 	"github.com/pkg/errors"
 	"github.com/spf13/cobra"
@@ -18,7 +17,7 @@ import (
 func (r *Root) initHTTP(measurementFlags *pflag.FlagSet, localFlags *pflag.FlagSet) {
 	httpCmd := &cobra.Command{
 		RunE:    r.RunHTTP,
-		Use:     "http [target] from [location | measurement ID | @1 | first | @-1 | last | previous]",
+		Use:     "http [target] from [location | measurement ID | @1 | first | @-1 | last | previous | #tag]",
 		GroupID: "Measurements",
 		Short:   "Perform a HEAD, GET, or OPTIONS request to a host",
 		Long: `The http command sends an HTTP request to a host and can perform a HEAD, GET, or OPTIONS operations, returning detailed performance statistics for each request. Use it to test and assess the performance and availability of your website, API, or other web services.
@@ -127,33 +126,15 @@ func (r *Root) RunHTTP(cmd *cobra.Command, args []string) error {
 		opts.Options.IPVersion = globalping.IPVersion6
 	}
 
-	res, err := r.client.CreateMeasurement(ctx, opts)
+	hm, err := r.createMeasurement(ctx, opts)
 
 	if err != nil {
-		cmd.SilenceUsage = silenceUsageOnCreateMeasurementError(err)
 		r.evaluateError(err)
 
 		return err
 	}
 
-	r.ctx.MeasurementsCreated++
-	hm := &view.HistoryItem{
-		Id:        res.ID,
-		Status:    globalping.MeasurementStatusInProgress,
-		StartedAt: r.utils.Now(),
-	}
-	r.ctx.History.Push(hm)
-
-	if r.ctx.RecordToSession {
-		r.ctx.RecordToSession = false
-		err := r.storage.SaveIdToSession(res.ID)
-
-		if err != nil {
-			r.printer.Printf("Warning: %s\n", err)
-		}
-	}
-
-	return r.handleMeasurement(ctx, res.ID, opts)
+	return r.handleMeasurement(ctx, hm.Id, opts)
 }
 
 const PostMeasurementTypeHttp = "http"
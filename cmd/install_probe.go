@@ -2,71 +2,220 @@ package cmd
 
 import (
 	"bufio"
+	"errors"
+	"fmt"
+	"strings"
 
 	"github.com/jsdelivr/globalping-cli/api/probe"
 	"github.com/spf13/cobra"
+	"github.com/spf13/pflag"
 )
 
+var ErrInvalidEnv = errors.New("invalid environment variable")
+
 func (r *Root) initInstallProbe() {
 	installProbeCmd := &cobra.Command{
 		Use:   "install-probe",
 		Short: "Join the Globalping network by running a probe",
-		Long:  `The install-probe command downloads and runs the Globalping probe in a Docker container on your machine. Requires you to have Docker installed.`,
-		Run:   r.RunInstallProbe,
+		Long: `The install-probe command downloads and runs the Globalping probe in a Docker container on your machine. Requires you to have Docker or Podman installed.
+Use --output to generate a systemd service, a Docker Compose file, or a Podman Quadlet unit instead, e.g. for configuration management.
+
+Examples:
+  # Install the probe without any prompts, e.g. from a provisioning script.
+  install-probe --yes --adoption-token <token>
+
+  # Run the probe with rootless Podman.
+  install-probe --engine podman --rootless
+
+  # Print a systemd service which runs the probe with Docker.
+  install-probe --output systemd
+
+  # Install a Podman Quadlet unit which links the probe to your account.
+  install-probe --output quadlet --adoption-token <token> --install`,
+		RunE: r.RunInstallProbe,
+		Args: cobra.NoArgs,
 	}
 
+	flags := installProbeCmd.Flags()
+	addContainerFlags(flags)
+	flags.String("image", probe.DefaultImage, "specify the image of the probe")
+	flags.StringArray("env", nil, "set an environment variable in the container as KEY=VALUE; may be repeated")
+	flags.String("adoption-token", "", "link the probe to your account using the adoption token from the Dashboard")
+	flags.BoolP("yes", "y", false, "install the probe without asking for confirmation (default false)")
+	flags.String("output", "", "generate a file instead of running the container: systemd, compose, or quadlet")
+	flags.Bool("install", false, "write the file generated by --output to its default location instead of printing it (default false)")
+	flags.Bool("force", false, "overwrite the file written by --install if it already exists (default false)")
+
 	r.Cmd.AddCommand(installProbeCmd)
 }
 
-func (r *Root) RunInstallProbe(_ *cobra.Command, _ []string) {
-	containerEngine, err := r.probe.DetectContainerEngine()
+func (r *Root) RunInstallProbe(cmd *cobra.Command, _ []string) error {
+	output, _ := cmd.Flags().GetString("output")
+	install, _ := cmd.Flags().GetBool("install")
+	force, _ := cmd.Flags().GetBool("force")
+	yes, _ := cmd.Flags().GetBool("yes")
+
+	if install && output == "" {
+		return errors.New("--install can only be used with --output")
+	}
+
+	if force && !install {
+		return errors.New("--force can only be used with --install")
+	}
+
+	c, err := r.containerFromFlags(cmd, output == "")
 
 	if err != nil {
-		r.printer.Printf("docker info command failed: %v\n\n", err)
-		r.printer.Println("Docker was not detected on your system and it is required to run the Globalping probe. Please install Docker and try again.")
+		return err
+	}
+
+	c.Image, _ = cmd.Flags().GetString("image")
+	env, _ := cmd.Flags().GetStringArray("env")
+
+	for _, e := range env {
+		if key, _, ok := strings.Cut(e, "="); !ok || key == "" {
+			return fmt.Errorf("%w: %s, must be KEY=VALUE", ErrInvalidEnv, e)
+		}
 
-		return
+		// Line breaks can't be represented in the generated files
+		if strings.ContainsAny(e, "\r\n") {
+			return fmt.Errorf("%w: %q, must not contain line breaks", ErrInvalidEnv, e)
+		}
+
+		c.Env = append(c.Env, e)
 	}
 
-	r.printer.Printf("Detected container engine: %s\n\n", containerEngine)
+	if adoptionToken, _ := cmd.Flags().GetString("adoption-token"); adoptionToken != "" {
+		c.Env = append(c.Env, probe.AdoptionTokenEnv+"="+adoptionToken)
+	}
 
-	err = r.probe.InspectContainer(containerEngine)
+	if output != "" {
+		return r.generateProbeArtifact(cmd, c, probe.ArtifactFormat(output), install, force)
+	}
+
+	if !cmd.Flags().Changed("engine") {
+		r.printer.Printf("Detected container engine: %s\n\n", c.Engine)
+	}
+
+	cmd.SilenceUsage = true
+	err = r.probe.InspectContainer(c)
 
 	if err != nil {
-		r.printer.Println(err)
+		if errors.Is(err, probe.ErrContainerExists) {
+			return fmt.Errorf("%w, use \"globalping probe update\" to update it or \"globalping probe uninstall\" to remove it", err)
+		}
 
-		return
+		return err
 	}
 
-	ok := r.askUser(containerPullMessage(containerEngine))
-
-	if !ok {
+	if !yes && !r.askUser(containerPullMessage(c)) {
 		r.printer.Println("You can also run a probe manually, check our GitHub for detailed instructions. Exited without changes.")
 
-		return
+		return nil
 	}
 
-	err = r.probe.RunContainer(containerEngine)
+	err = r.probe.RunContainer(c)
 
 	if err != nil {
-		r.printer.Println(err)
-
-		return
+		return fmt.Errorf("failed to run the container: %w", err)
 	}
 
 	r.printer.Printf("The Globalping probe started successfully. Thank you for joining our community! \n")
 
-	if containerEngine == probe.ContainerEnginePodman {
+	if c.Engine == probe.ContainerEnginePodman {
 		r.printer.Printf("When you are using Podman, you also need to install a service to make sure the container starts on boot. Please see our instructions here: https://github.com/jsdelivr/globalping-probe/blob/master/README.md#podman-alternative\n")
 	}
+
+	return nil
+}
+
+// Prints or installs a file which runs the probe, without running any container commands.
+func (r *Root) generateProbeArtifact(cmd *cobra.Command, c *probe.Container, format probe.ArtifactFormat, install bool, force bool) error {
+	content, err := probe.GenerateArtifact(format, c)
+
+	if err != nil {
+		return err
+	}
+
+	if !install {
+		r.printer.Print(content)
+
+		return nil
+	}
+
+	cmd.SilenceUsage = true
+	path, err := r.probe.WriteArtifact(format, c, content, force)
+
+	if err != nil {
+		if errors.Is(err, probe.ErrArtifactExists) {
+			return fmt.Errorf("%w, use --force to overwrite it", err)
+		}
+
+		return err
+	}
+
+	r.printer.Printf("Saved to %s. Start the probe with:\n", path)
+	systemctl := "systemctl"
+
+	if c.Rootless {
+		systemctl = "systemctl --user"
+	}
+
+	switch format {
+	case probe.ArtifactSystemd:
+		r.printer.Printf("  %[1]s daemon-reload && %[1]s enable --now %[2]s\n", systemctl, c.Name)
+	case probe.ArtifactQuadlet:
+		r.printer.Printf("  %[1]s daemon-reload && %[1]s start %[2]s\n", systemctl, c.Name)
+	case probe.ArtifactCompose:
+		r.printer.Println("  docker compose up -d")
+	}
+
+	return nil
+}
+
+// Adds the flags which select the probe container, shared by install-probe and the probe commands.
+func addContainerFlags(flags *pflag.FlagSet) {
+	flags.String("name", probe.DefaultContainerName, "specify the name of the probe container")
+	flags.String("engine", "", "specify the container engine, docker or podman (default detected automatically)")
+	flags.Bool("rootless", false, "run Podman without sudo (default false)")
+}
+
+// Returns the container selected by the flags. If --engine is not provided, the engine is detected if detect is true, and Docker is assumed otherwise.
+func (r *Root) containerFromFlags(cmd *cobra.Command, detect bool) (*probe.Container, error) {
+	engineName, _ := cmd.Flags().GetString("engine")
+	containerEngine := probe.ContainerEngineDocker
+
+	if engineName != "" {
+		var err error
+		containerEngine, err = probe.ParseContainerEngine(engineName)
+
+		if err != nil {
+			return nil, err
+		}
+	} else if detect {
+		var err error
+		containerEngine, err = r.probe.DetectContainerEngine()
+
+		if err != nil {
+			cmd.SilenceUsage = true
+
+			return nil, err
+		}
+	}
+
+	c := probe.NewContainer(containerEngine)
+	c.Name, _ = cmd.Flags().GetString("name")
+	c.Rootless, _ = cmd.Flags().GetBool("rootless")
+
+	return c, nil
 }
 
-func containerPullMessage(containerEngine probe.ContainerEngine) string {
+func containerPullMessage(c *probe.Container) string {
 	pre := "The Globalping platform is a community powered project and relies on individuals like yourself to host our probes and make them accessible to everyone else.\n"
 	var mid string
-	post := "Please confirm to pull and run our Docker container (globalping/globalping-probe)"
+	post := fmt.Sprintf("Please confirm to pull and run our Docker container (%s)", c.Image)
 
-	if containerEngine == probe.ContainerEnginePodman {
+	if c.Engine == probe.ContainerEnginePodman && !c.Rootless {
 		mid = "We have detected that you are using podman, the 'sudo podman' command will be used to pull the container.\n"
 	}
 
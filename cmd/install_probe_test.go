@@ -2,6 +2,8 @@ package cmd
 
 import (
 	"bytes"
+	"errors"
+	"fmt"
 	"os"
 	"testing"
 
@@ -18,8 +20,8 @@ func Test_Execute_Install_Probe_Docker(t *testing.T) {
 
 	probeMock := apiMocks.NewMockProbe(ctrl)
 	probeMock.EXPECT().DetectContainerEngine().Times(1).Return(probe.ContainerEngineDocker, nil)
-	probeMock.EXPECT().InspectContainer(probe.ContainerEngineDocker).Times(1).Return(nil)
-	probeMock.EXPECT().RunContainer(probe.ContainerEngineDocker).Times(1).Return(nil)
+	probeMock.EXPECT().InspectContainer(probe.NewContainer(probe.ContainerEngineDocker)).Times(1).Return(nil)
+	probeMock.EXPECT().RunContainer(probe.NewContainer(probe.ContainerEngineDocker)).Times(1).Return(nil)
 
 	reader := bytes.NewReader([]byte("Y\n"))
 	w := new(bytes.Buffer)
@@ -46,3 +48,257 @@ Please confirm to pull and run our Docker container (globalping/globalping-probe
 	}
 	assert.Equal(t, expectedCtx, ctx)
 }
+
+func Test_Execute_Install_Probe_Non_Interactive(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	c := &probe.Container{
+		Engine:   probe.ContainerEnginePodman,
+		Name:     "probe-1",
+		Image:    "ghcr.io/example/probe:latest",
+		Rootless: true,
+		Env:      []string{"NODE_ENV=production", "GP_ADOPTION_TOKEN=abc"},
+	}
+
+	probeMock := apiMocks.NewMockProbe(ctrl)
+	probeMock.EXPECT().InspectContainer(c).Return(nil)
+	probeMock.EXPECT().RunContainer(c).Return(nil)
+
+	w := new(bytes.Buffer)
+	printer := view.NewPrinter(nil, w, w)
+	ctx := createDefaultContext()
+	root := NewRoot(printer, ctx, nil, nil, nil, probeMock, nil)
+	os.Args = []string{"globalping", "install-probe", "--yes", "--engine", "podman", "--rootless", "--name", "probe-1",
+		"--image", "ghcr.io/example/probe:latest", "--env", "NODE_ENV=production", "--adoption-token", "abc"}
+
+	err := root.Cmd.ExecuteContext(t.Context())
+	assert.NoError(t, err)
+
+	assert.Equal(t, `The Globalping probe started successfully. Thank you for joining our community! 
+When you are using Podman, you also need to install a service to make sure the container starts on boot. Please see our instructions here: https://github.com/jsdelivr/globalping-probe/blob/master/README.md#podman-alternative
+`, w.String())
+}
+
+func Test_Execute_Install_Probe_Container_Exists(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	probeMock := apiMocks.NewMockProbe(ctrl)
+	probeMock.EXPECT().DetectContainerEngine().Return(probe.ContainerEngineDocker, nil)
+	probeMock.EXPECT().InspectContainer(probe.NewContainer(probe.ContainerEngineDocker)).
+		Return(fmt.Errorf("%w: globalping-probe (status: running)", probe.ErrContainerExists))
+
+	w := new(bytes.Buffer)
+	printer := view.NewPrinter(nil, w, w)
+	ctx := createDefaultContext()
+	root := NewRoot(printer, ctx, nil, nil, nil, probeMock, nil)
+	os.Args = []string{"globalping", "install-probe", "--yes"}
+
+	err := root.Cmd.ExecuteContext(t.Context())
+	assert.ErrorIs(t, err, probe.ErrContainerExists)
+
+	assert.Equal(t, `Detected container engine: Docker
+
+Error: container already exists: globalping-probe (status: running), use "globalping probe update" to update it or "globalping probe uninstall" to remove it
+`, w.String())
+}
+
+func Test_Execute_Install_Probe_No_Engine(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	probeMock := apiMocks.NewMockProbe(ctrl)
+	probeMock.EXPECT().DetectContainerEngine().Return(probe.ContainerEngineUnknown,
+		fmt.Errorf("%w: %w", probe.ErrNoContainerEngine, &probe.CommandError{Command: "docker info", Err: errors.New("exit status 1")}))
+
+	w := new(bytes.Buffer)
+	printer := view.NewPrinter(nil, w, w)
+	ctx := createDefaultContext()
+	root := NewRoot(printer, ctx, nil, nil, nil, probeMock, nil)
+	os.Args = []string{"globalping", "install-probe"}
+
+	err := root.Cmd.ExecuteContext(t.Context())
+	assert.ErrorIs(t, err, probe.ErrNoContainerEngine)
+
+	assert.Equal(t, "Error: no container engine detected: docker info: exit status 1\n", w.String())
+}
+
+func Test_Execute_Install_Probe_Invalid_Env(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	probeMock := apiMocks.NewMockProbe(ctrl)
+
+	w := new(bytes.Buffer)
+	printer := view.NewPrinter(nil, w, w)
+	ctx := createDefaultContext()
+	root := NewRoot(printer, ctx, nil, nil, nil, probeMock, nil)
+	os.Args = []string{"globalping", "install-probe", "--engine", "docker", "--env", "NODE_ENV"}
+
+	err := root.Cmd.ExecuteContext(t.Context())
+	assert.ErrorIs(t, err, ErrInvalidEnv)
+}
+
+func Test_Execute_Install_Probe_Output_Systemd(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	probeMock := apiMocks.NewMockProbe(ctrl)
+
+	w := new(bytes.Buffer)
+	printer := view.NewPrinter(nil, w, w)
+	ctx := createDefaultContext()
+	root := NewRoot(printer, ctx, nil, nil, nil, probeMock, nil)
+	os.Args = []string{"globalping", "install-probe", "--output", "systemd", "--adoption-token", "abc"}
+
+	err := root.Cmd.ExecuteContext(t.Context())
+	assert.NoError(t, err)
+
+	assert.Equal(t, `[Unit]
+Description=Globalping probe
+After=docker.service network-online.target
+Requires=docker.service
+Wants=network-online.target
+
+[Service]
+ExecStartPre=-/usr/bin/docker rm --force globalping-probe
+ExecStart=/usr/bin/docker run --rm --log-driver local --network host --name globalping-probe --env "GP_ADOPTION_TOKEN=abc" globalping/globalping-probe
+ExecStop=/usr/bin/docker stop globalping-probe
+Restart=always
+RestartSec=10
+
+[Install]
+WantedBy=multi-user.target
+`, w.String())
+}
+
+func Test_Execute_Install_Probe_Output_Compose(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	probeMock := apiMocks.NewMockProbe(ctrl)
+
+	w := new(bytes.Buffer)
+	printer := view.NewPrinter(nil, w, w)
+	ctx := createDefaultContext()
+	root := NewRoot(printer, ctx, nil, nil, nil, probeMock, nil)
+	os.Args = []string{"globalping", "install-probe", "--output", "compose"}
+
+	err := root.Cmd.ExecuteContext(t.Context())
+	assert.NoError(t, err)
+
+	assert.Equal(t, `services:
+  globalping-probe:
+    image: globalping/globalping-probe
+    container_name: globalping-probe
+    network_mode: host
+    restart: always
+    logging:
+      driver: local
+`, w.String())
+}
+
+func Test_Execute_Install_Probe_Output_Quadlet_Install(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	probeMock := apiMocks.NewMockProbe(ctrl)
+	probeMock.EXPECT().WriteArtifact(probe.ArtifactQuadlet, createContainerWithToken(), `[Unit]
+Description=Globalping probe
+Wants=network-online.target
+After=network-online.target
+
+[Container]
+Image=docker.io/globalping/globalping-probe
+ContainerName=globalping-probe
+Network=host
+AddCapability=NET_RAW
+Environment="GP_ADOPTION_TOKEN=abc"
+
+[Service]
+Restart=always
+
+[Install]
+WantedBy=multi-user.target default.target
+`, false).Return("/etc/containers/systemd/globalping-probe.container", nil)
+
+	w := new(bytes.Buffer)
+	printer := view.NewPrinter(nil, w, w)
+	ctx := createDefaultContext()
+	root := NewRoot(printer, ctx, nil, nil, nil, probeMock, nil)
+	os.Args = []string{"globalping", "install-probe", "--output", "quadlet", "--adoption-token", "abc", "--install"}
+
+	err := root.Cmd.ExecuteContext(t.Context())
+	assert.NoError(t, err)
+
+	assert.Equal(t, `Saved to /etc/containers/systemd/globalping-probe.container. Start the probe with:
+  systemctl daemon-reload && systemctl start globalping-probe
+`, w.String())
+}
+
+func Test_Execute_Install_Probe_Output_Systemd_Install_Rootless(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	expectedContainer := probe.NewContainer(probe.ContainerEnginePodman)
+	expectedContainer.Rootless = true
+
+	probeMock := apiMocks.NewMockProbe(ctrl)
+	probeMock.EXPECT().WriteArtifact(probe.ArtifactSystemd, expectedContainer, gomock.Any(), true).Return("/home/user/.config/systemd/user/globalping-probe.service", nil)
+
+	w := new(bytes.Buffer)
+	printer := view.NewPrinter(nil, w, w)
+	ctx := createDefaultContext()
+	root := NewRoot(printer, ctx, nil, nil, nil, probeMock, nil)
+	os.Args = []string{"globalping", "install-probe", "--output", "systemd", "--engine", "podman", "--rootless", "--install", "--force"}
+
+	err := root.Cmd.ExecuteContext(t.Context())
+	assert.NoError(t, err)
+
+	assert.Equal(t, `Saved to /home/user/.config/systemd/user/globalping-probe.service. Start the probe with:
+  systemctl --user daemon-reload && systemctl --user enable --now globalping-probe
+`, w.String())
+}
+
+func Test_Execute_Install_Probe_Output_Install_Exists(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	probeMock := apiMocks.NewMockProbe(ctrl)
+	probeMock.EXPECT().WriteArtifact(probe.ArtifactCompose, gomock.Any(), gomock.Any(), false).Return("", fmt.Errorf("%w: compose.yaml", probe.ErrArtifactExists))
+
+	w := new(bytes.Buffer)
+	printer := view.NewPrinter(nil, w, w)
+	ctx := createDefaultContext()
+	root := NewRoot(printer, ctx, nil, nil, nil, probeMock, nil)
+	os.Args = []string{"globalping", "install-probe", "--output", "compose", "--install"}
+
+	err := root.Cmd.ExecuteContext(t.Context())
+	assert.ErrorIs(t, err, probe.ErrArtifactExists)
+
+	assert.Equal(t, "Error: file already exists: compose.yaml, use --force to overwrite it\n", w.String())
+}
+
+func Test_Execute_Install_Probe_Output_Invalid(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	probeMock := apiMocks.NewMockProbe(ctrl)
+
+	w := new(bytes.Buffer)
+	printer := view.NewPrinter(nil, w, w)
+	ctx := createDefaultContext()
+	root := NewRoot(printer, ctx, nil, nil, nil, probeMock, nil)
+	os.Args = []string{"globalping", "install-probe", "--output", "helm"}
+
+	err := root.Cmd.ExecuteContext(t.Context())
+	assert.ErrorIs(t, err, probe.ErrInvalidArtifactFormat)
+}
+
+func createContainerWithToken() *probe.Container {
+	c := probe.NewContainer(probe.ContainerEngineDocker)
+	c.Env = []string{"GP_ADOPTION_TOKEN=abc"}
+
+	return c
+}
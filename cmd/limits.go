@@ -1,24 +1,77 @@
 package cmd
 
 import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os/signal"
+	"strings"
+	"syscall"
+	"time"
+
 	"github.com/jsdelivr/globalping-cli/utils"
 	"github.com/jsdelivr/globalping-go"
 	"github.com/spf13/cobra"
 )
 
+type limitsOutput struct {
+	Authentication string              `json:"authentication"` // "token" or "ip"
+	Username       string              `json:"username,omitempty"`
+	Create         limitsCreateOutput  `json:"create"`
+	Credits        *limitsCreditOutput `json:"credits,omitempty"` // Only available for users
+	Rate           *float64            `json:"rate,omitempty"`    // Tests and credits used per minute, only in watch mode
+}
+
+type limitsCreateOutput struct {
+	Type      string `json:"type"`
+	Limit     int64  `json:"limit"`
+	Consumed  int64  `json:"consumed"`
+	Remaining int64  `json:"remaining"`
+	Reset     int64  `json:"reset"` // Seconds
+}
+
+type limitsCreditOutput struct {
+	Remaining int64 `json:"remaining"`
+}
+
+// Tracks the usage between the samples of limits --watch.
+type limitsUsage struct {
+	startedAt time.Time
+	consumed  int64
+	credits   int64
+}
+
 func (r *Root) initLimits() {
 	limitsCmd := &cobra.Command{
 		Use:   "limits",
 		Short: "Show the current rate limits",
-		Long:  `Show the current rate limits.`,
-		RunE:  r.RunLimits,
+		Long: `Show the current rate limits.
+
+Examples:
+  # Show the rate limits and the remaining credits.
+  limits
+
+  # Output the limits in JSON format, e.g. to check the remaining credits in a script.
+  limits --json
+
+  # Refresh the limits every 10 seconds and show how fast they are consumed.
+  limits --watch --interval 10s`,
+		RunE: r.RunLimits,
+		Args: cobra.NoArgs,
 	}
 
+	flags := limitsCmd.Flags()
+	flags.BoolP("json", "J", false, "output the limits in JSON format (default false)")
+	flags.Bool("watch", false, "refresh the limits periodically and show the consumption rate until stopped (default false)")
+	flags.Duration("interval", 30*time.Second, "specify how often the limits are refreshed with --watch")
+
 	r.Cmd.AddCommand(limitsCmd)
 }
 
 func (r *Root) RunLimits(cmd *cobra.Command, _ []string) error {
 	ctx := cmd.Context()
+	toJSON, _ := cmd.Flags().GetBool("json")
+	watch, _ := cmd.Flags().GetBool("watch")
 
 	introspection, _ := r.client.TokenIntrospection(ctx, "")
 	username := ""
@@ -27,44 +80,183 @@ func (r *Root) RunLimits(cmd *cobra.Command, _ []string) error {
 		username = introspection.Username
 	}
 
+	if watch {
+		interval, _ := cmd.Flags().GetDuration("interval")
+
+		if interval < time.Second {
+			return fmt.Errorf("interval must be at least 1s")
+		}
+
+		cmd.SilenceUsage = true
+
+		return r.watchLimits(ctx, username, interval, toJSON)
+	}
+
 	limits, err := r.client.Limits(ctx)
 
 	if err != nil {
 		return err
 	}
 
-	createLimit := utils.Pluralize(limits.RateLimits.Measurements.Create.Limit, "test")
-	createConsumed := limits.RateLimits.Measurements.Create.Limit - limits.RateLimits.Measurements.Create.Remaining
-	createRemaining := limits.RateLimits.Measurements.Create.Remaining
-	t := limits.RateLimits.Measurements.Create.Type
+	output := newLimitsOutput(limits, username)
+
+	if toJSON {
+		return r.printJSON(output)
+	}
+
+	r.printer.Print(formatLimits(output))
+
+	return nil
+}
+
+// Prints the limits on each interval until interrupted. In CI mode, with --json, and when stdout is not a terminal,
+// each sample is printed on its own instead of redrawing the previous one.
+func (r *Root) watchLimits(ctx context.Context, username string, interval time.Duration, toJSON bool) error {
+	terminal, err := r.isOutputTerminal()
+
+	if err != nil {
+		return err
+	}
+
+	plain := r.ctx.CIMode || !terminal
+	ctx, stop := context.WithCancel(ctx)
+	defer stop()
+	signal.Notify(r.cancel, syscall.SIGINT, syscall.SIGTERM)
+	defer signal.Stop(r.cancel)
+
+	go func() {
+		select {
+		case <-r.cancel:
+			stop()
+		case <-ctx.Done():
+		}
+	}()
+
+	var usage *limitsUsage
+
+	for {
+		limits, err := r.client.Limits(ctx)
+
+		if err != nil {
+			if ctx.Err() != nil {
+				return nil
+			}
+
+			return err
+		}
+
+		output := newLimitsOutput(limits, username)
+		rate := 0.0
+		now := r.utils.Now()
+
+		// The baseline is reset when the hourly limit resets
+		if usage == nil || output.Create.Consumed < usage.consumed {
+			usage = &limitsUsage{startedAt: now, consumed: output.Create.Consumed, credits: creditsRemaining(output)}
+		} else if elapsed := now.Sub(usage.startedAt); elapsed > 0 {
+			used := output.Create.Consumed - usage.consumed + usage.credits - creditsRemaining(output)
+			rate = float64(used) / elapsed.Minutes()
+			output.Rate = &rate
+		}
+
+		switch {
+		case toJSON:
+			b, err := json.Marshal(output)
+
+			if err != nil {
+				return err
+			}
+
+			r.printer.Println(string(b))
+		case plain:
+			r.printer.Printf("%s%s\n", formatLimits(output), formatLimitsRate(output))
+		default:
+			content := fmt.Sprintf("%s%s\nUpdated at %s, refreshing every %s. Press Ctrl+C to stop.\n",
+				formatLimits(output),
+				formatLimitsRate(output),
+				now.Format(time.TimeOnly),
+				interval,
+			)
+			r.printer.AreaUpdate(&content)
+		}
+
+		timer := time.NewTimer(interval)
+
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+
+			return nil
+		case <-timer.C:
+		}
+	}
+}
+
+func newLimitsOutput(limits *globalping.LimitsResponse, username string) *limitsOutput {
+	create := limits.RateLimits.Measurements.Create
+	output := &limitsOutput{
+		Authentication: "ip",
+		Create: limitsCreateOutput{
+			Type:      create.Type,
+			Limit:     create.Limit,
+			Consumed:  create.Limit - create.Remaining,
+			Remaining: create.Remaining,
+			Reset:     create.Reset,
+		},
+	}
 
-	if t == globalping.CreateLimitTypeUser {
-		r.printer.Printf("Authentication: token (%s)\n\n", username)
+	if create.Type == globalping.CreateLimitTypeUser {
+		output.Authentication = "token"
+		output.Username = username
+		output.Credits = &limitsCreditOutput{Remaining: limits.Credits.Remaining}
+	}
+
+	return output
+}
+
+func formatLimits(output *limitsOutput) string {
+	var sb strings.Builder
+
+	if output.Create.Type == globalping.CreateLimitTypeUser {
+		sb.WriteString(fmt.Sprintf("Authentication: token (%s)\n\n", output.Username))
 	} else {
-		r.printer.Printf("Authentication: IP address\n\n")
+		sb.WriteString("Authentication: IP address\n\n")
 	}
 
-	r.printer.Printf(`Creating measurements:
+	sb.WriteString(fmt.Sprintf(`Creating measurements:
  - %s per hour
  - %d consumed, %d remaining
 `,
-		createLimit,
-		createConsumed,
-		createRemaining,
-	)
-
-	if limits.RateLimits.Measurements.Create.Reset > 0 {
-		createResets := utils.FormatSeconds(limits.RateLimits.Measurements.Create.Reset)
-		r.printer.Printf(" - resets in %s\n", createResets)
+		utils.Pluralize(output.Create.Limit, "test"),
+		output.Create.Consumed,
+		output.Create.Remaining,
+	))
+
+	if output.Create.Reset > 0 {
+		sb.WriteString(fmt.Sprintf(" - resets in %s\n", utils.FormatSeconds(output.Create.Reset)))
 	}
 
-	if t == globalping.CreateLimitTypeUser {
-		credits := utils.Pluralize(limits.Credits.Remaining, "credit")
-		r.printer.Printf(`
+	if output.Credits != nil {
+		sb.WriteString(fmt.Sprintf(`
 Credits:
  - %s remaining (may be used to create measurements above the hourly limits)
-`, credits)
+`, utils.Pluralize(output.Credits.Remaining, "credit")))
 	}
 
-	return nil
+	return sb.String()
+}
+
+func formatLimitsRate(output *limitsOutput) string {
+	if output.Rate == nil {
+		return "\nConsumption rate: measuring...\n"
+	}
+
+	return fmt.Sprintf("\nConsumption rate: %.1f tests and credits per minute\n", *output.Rate)
+}
+
+func creditsRemaining(output *limitsOutput) int64 {
+	if output.Credits == nil {
+		return 0
+	}
+
+	return output.Credits.Remaining
 }
@@ -3,10 +3,14 @@ package cmd
 import (
 	"bytes"
 	"os"
+	"strings"
+	"syscall"
 	"testing"
+	"time"
 
 	"github.com/jsdelivr/globalping-cli/api"
 	apiMocks "github.com/jsdelivr/globalping-cli/mocks/api"
+	utilsMocks "github.com/jsdelivr/globalping-cli/mocks/utils"
 	"github.com/jsdelivr/globalping-cli/view"
 	"github.com/jsdelivr/globalping-go"
 	"github.com/stretchr/testify/assert"
@@ -149,3 +153,142 @@ Creating measurements:
  - resets in 10 minutes
 `, w.String())
 }
+
+func Test_Limits_JSON(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	gbMock := apiMocks.NewMockClient(ctrl)
+
+	gbMock.EXPECT().TokenIntrospection(t.Context(), "").Return(&api.IntrospectionResponse{
+		Active:   true,
+		Username: "test",
+	}, nil)
+	gbMock.EXPECT().Limits(t.Context()).Return(createUserLimitsResponse(350, 1000), nil)
+
+	w := new(bytes.Buffer)
+	printer := view.NewPrinter(nil, w, w)
+	ctx := createDefaultContext()
+
+	root := NewRoot(printer, ctx, nil, nil, gbMock, nil, nil)
+
+	os.Args = []string{"globalping", "limits", "--json"}
+	err := root.Cmd.ExecuteContext(t.Context())
+	assert.NoError(t, err)
+
+	assert.Equal(t, `{
+  "authentication": "token",
+  "username": "test",
+  "create": {
+    "type": "user",
+    "limit": 500,
+    "consumed": 150,
+    "remaining": 350,
+    "reset": 600
+  },
+  "credits": {
+    "remaining": 1000
+  }
+}
+`, w.String())
+}
+
+func Test_Limits_Watch(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	gbMock := apiMocks.NewMockClient(ctrl)
+	utilsMock := utilsMocks.NewMockUtils(ctrl)
+
+	w := new(bytes.Buffer)
+	printer := view.NewPrinter(nil, w, w)
+	ctx := createDefaultContext()
+
+	root := NewRoot(printer, ctx, nil, utilsMock, gbMock, nil, nil)
+
+	gbMock.EXPECT().TokenIntrospection(t.Context(), "").Return(&api.IntrospectionResponse{
+		Active:   true,
+		Username: "test",
+	}, nil)
+	gomock.InOrder(
+		utilsMock.EXPECT().Now().Return(defaultCurrentTime),
+		utilsMock.EXPECT().Now().Return(defaultCurrentTime.Add(2*time.Minute)),
+	)
+	gomock.InOrder(
+		gbMock.EXPECT().Limits(gomock.Any()).Return(createUserLimitsResponse(350, 1000), nil),
+		gbMock.EXPECT().Limits(gomock.Any()).DoAndReturn(func(_ any) (*globalping.LimitsResponse, error) {
+			go func() {
+				root.cancel <- syscall.SIGINT
+			}()
+
+			return createUserLimitsResponse(340, 990), nil
+		}),
+	)
+
+	os.Args = []string{"globalping", "limits", "--watch", "--interval", "1s", "--json"}
+	err := root.Cmd.ExecuteContext(t.Context())
+	assert.NoError(t, err)
+
+	assert.Equal(t, `{"authentication":"token","username":"test","create":{"type":"user","limit":500,"consumed":150,"remaining":350,"reset":600},"credits":{"remaining":1000}}
+{"authentication":"token","username":"test","create":{"type":"user","limit":500,"consumed":160,"remaining":340,"reset":600},"credits":{"remaining":990},"rate":10}
+`, w.String())
+}
+
+func Test_Limits_Watch_NotTerminal(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	gbMock := apiMocks.NewMockClient(ctrl)
+	utilsMock := utilsMocks.NewMockUtils(ctrl)
+
+	// The output is not a terminal, so the samples are printed one after another without redrawing
+	w := new(bytes.Buffer)
+	printer := view.NewPrinter(nil, w, w)
+	ctx := createDefaultContext()
+	ctx.CIMode = false
+
+	root := NewRoot(printer, ctx, nil, utilsMock, gbMock, nil, nil)
+
+	gbMock.EXPECT().TokenIntrospection(t.Context(), "").Return(nil, nil)
+	gomock.InOrder(
+		utilsMock.EXPECT().Now().Return(defaultCurrentTime),
+		utilsMock.EXPECT().Now().Return(defaultCurrentTime.Add(2*time.Minute)),
+	)
+	gomock.InOrder(
+		gbMock.EXPECT().Limits(gomock.Any()).Return(createUserLimitsResponse(350, 1000), nil),
+		gbMock.EXPECT().Limits(gomock.Any()).DoAndReturn(func(_ any) (*globalping.LimitsResponse, error) {
+			go func() {
+				root.cancel <- syscall.SIGINT
+			}()
+
+			return createUserLimitsResponse(340, 990), nil
+		}),
+	)
+
+	os.Args = []string{"globalping", "limits", "--watch", "--interval", "1s"}
+	err := root.Cmd.ExecuteContext(t.Context())
+	assert.NoError(t, err)
+
+	assert.NotContains(t, w.String(), "\033")
+	assert.Equal(t, 2, strings.Count(w.String(), "Creating measurements:"))
+	assert.Contains(t, w.String(), "Consumption rate: measuring...\n")
+	assert.Contains(t, w.String(), "Consumption rate: 10.0 tests and credits per minute\n")
+}
+
+func createUserLimitsResponse(remaining int64, credits int64) *globalping.LimitsResponse {
+	return &globalping.LimitsResponse{
+		RateLimits: globalping.RateLimits{
+			Measurements: globalping.MeasurementsLimits{
+				Create: globalping.MeasurementsCreateLimits{
+					Type:      "user",
+					Limit:     500,
+					Remaining: remaining,
+					Reset:     600,
+				},
+			},
+		},
+		Credits: globalping.CreditLimits{
+			Remaining: credits,
+		},
+	}
+}
@@ -0,0 +1,338 @@
+package cmd
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	"github.com/jsdelivr/globalping-cli/utils"
+	"github.com/jsdelivr/globalping-go"
+	"github.com/spf13/cobra"
+)
+
+var (
+	probesCacheTTL = time.Hour
+
+	// The probe list is only needed for the warnings of unknown locations, so the measurement doesn't wait long for it
+	probesFetchTimeout = 3 * time.Second
+)
+
+type locationPart struct {
+	Value      string
+	Probes     int
+	Suggestion string // The closest known name if no probes match
+}
+
+type locationCheck struct {
+	Location string
+	Parts    []*locationPart
+	Probes   int // Probes matching all parts
+}
+
+func (r *Root) initLocation() {
+	locationCmd := &cobra.Command{
+		Use:   "location",
+		Short: "Work with probe locations",
+		Long:  `Work with probe locations.`,
+	}
+
+	checkCmd := &cobra.Command{
+		RunE:  r.RunLocationCheck,
+		Use:   "check [location]",
+		Short: "Show how many probes match a location",
+		Long: `Show how many online probes match each location and each of its parts, and suggest close matches for unknown names.
+Use it to build a "from" value before running a measurement. The probe list is cached for an hour.
+
+Examples:
+  # Check a location combining a city and a network.
+  location check "Frankfurt+AWS"
+
+  # Check multiple locations.
+  location check "Germany, Comcast+Seattle"`,
+		Args: cobra.MinimumNArgs(1),
+	}
+
+	locationCmd.AddCommand(checkCmd)
+
+	r.Cmd.AddCommand(locationCmd)
+}
+
+func (r *Root) RunLocationCheck(cmd *cobra.Command, args []string) error {
+	cmd.SilenceUsage = true
+	probes, err := r.loadProbes(cmd.Context(), true)
+
+	if err != nil {
+		return err
+	}
+
+	w := tabwriter.NewWriter(r.printer.OutWriter, 0, 0, 2, ' ', 0)
+
+	for _, location := range strings.Split(strings.Join(args, ","), ",") {
+		location = strings.TrimSpace(location)
+
+		if location == "" {
+			continue
+		}
+
+		check := checkLocation(probes, location)
+		_, _ = fmt.Fprintf(w, "%s\t%s\n", check.Location, utils.Pluralize(int64(check.Probes), "probe"))
+
+		if len(check.Parts) < 2 && check.Parts[0].Suggestion == "" {
+			continue
+		}
+
+		for _, part := range check.Parts {
+			_, _ = fmt.Fprintf(w, "  %s\t%s", part.Value, utils.Pluralize(int64(part.Probes), "probe"))
+
+			if part.Suggestion != "" {
+				_, _ = fmt.Fprintf(w, ", did you mean %s?", part.Suggestion)
+			}
+
+			_, _ = fmt.Fprintln(w)
+		}
+	}
+
+	return w.Flush()
+}
+
+// Checks the locations of the measurement against the probe list, so that typos are reported before the measurement is created.
+// The list is fetched if the cache is empty or expired. If that fails, the measurement is created without the check.
+// Close matches are only printed as warnings, as the API also accepts aliases and probes which are not in the probe list.
+func (r *Root) warnUnknownLocations(ctx context.Context, opts *globalping.MeasurementCreate) {
+	locations, ok := opts.Locations.(globalping.LocationOptions)
+
+	if !ok || r.storage == nil || r.ctx.MeasurementsCreated > 0 || !hasMagicLocation(locations) {
+		return
+	}
+
+	fetchCtx, cancel := context.WithTimeout(ctx, probesFetchTimeout)
+	defer cancel()
+	probes, err := r.loadProbes(fetchCtx, true)
+
+	if err != nil {
+		return
+	}
+
+	for _, location := range locations {
+		if location.Magic == "" {
+			continue
+		}
+
+		for _, part := range checkLocation(probes, location.Magic).Parts {
+			if part.Suggestion != "" {
+				r.printer.ErrPrintf("Warning: no probes found in %s, did you mean %s?\n", part.Value, part.Suggestion)
+			}
+		}
+	}
+}
+
+func hasMagicLocation(locations globalping.LocationOptions) bool {
+	for _, location := range locations {
+		if location.Magic != "" {
+			return true
+		}
+	}
+
+	return false
+}
+
+// Adds a suggestion to the API error if one of the locations looks like a typo.
+func (r *Root) suggestLocation(ctx context.Context, opts *globalping.MeasurementCreate, apiErr *globalping.MeasurementError) {
+	locations, ok := opts.Locations.(globalping.LocationOptions)
+
+	if !ok || r.storage == nil {
+		return
+	}
+
+	probes, err := r.loadProbes(ctx, true)
+
+	if err != nil {
+		return
+	}
+
+	for _, location := range locations {
+		if location.Magic == "" {
+			continue
+		}
+
+		for _, part := range checkLocation(probes, location.Magic).Parts {
+			if part.Suggestion != "" {
+				apiErr.Message += fmt.Sprintf(`. Did you mean %s instead of "%s"?`, part.Suggestion, part.Value)
+
+				return
+			}
+		}
+	}
+}
+
+// Returns the probe list from the cache. If the cache is expired and fetch is true, the list is fetched from the API.
+func (r *Root) loadProbes(ctx context.Context, fetch bool) ([]globalping.Probe, error) {
+	if r.storage != nil {
+		b, err := r.storage.GetCachedProbes(probesCacheTTL)
+
+		if err == nil && b != nil {
+			probes := []globalping.Probe{}
+
+			if err := json.Unmarshal(b, &probes); err == nil {
+				return probes, nil
+			}
+		}
+	}
+
+	if !fetch {
+		return nil, nil
+	}
+
+	res, err := r.client.Probes(ctx)
+
+	if err != nil {
+		return nil, err
+	}
+
+	if r.storage != nil {
+		b, _ := json.Marshal(res)
+		err := r.storage.SaveCachedProbes(b)
+
+		if err != nil {
+			r.printer.ErrPrintf("Warning: failed to cache the probe list: %s\n", err)
+		}
+	}
+
+	return *res, nil
+}
+
+func checkLocation(probes []globalping.Probe, location string) *locationCheck {
+	check := &locationCheck{Location: location}
+
+	for _, v := range strings.Split(location, "+") {
+		check.Parts = append(check.Parts, &locationPart{Value: strings.TrimSpace(v)})
+	}
+
+	for _, p := range probes {
+		matchesAll := true
+
+		for _, part := range check.Parts {
+			if probeMatchesLocation(p, part.Value) {
+				part.Probes++
+			} else {
+				matchesAll = false
+			}
+		}
+
+		if matchesAll {
+			check.Probes++
+		}
+	}
+
+	for _, part := range check.Parts {
+		if part.Probes == 0 {
+			part.Suggestion = suggestLocationName(probes, part.Value)
+		}
+	}
+
+	return check
+}
+
+func probeMatchesLocation(p globalping.Probe, value string) bool {
+	value = strings.ToLower(value)
+	l := p.Location
+
+	if value == "world" {
+		return true
+	}
+
+	if asn, ok := strings.CutPrefix(value, "as"); ok {
+		if n, err := strconv.Atoi(asn); err == nil {
+			return l.ASN == n
+		}
+	}
+
+	if n, err := strconv.Atoi(value); err == nil {
+		return l.ASN == n
+	}
+
+	for _, name := range []string{
+		l.Continent,
+		continentNames[l.Continent],
+		l.Region,
+		l.Country,
+		countryNames[l.Country],
+		l.State,
+		usStateNames[l.State],
+		l.City,
+	} {
+		if name != "" && strings.ToLower(name) == value {
+			return true
+		}
+	}
+
+	for _, tag := range p.Tags {
+		if strings.ToLower(tag) == value {
+			return true
+		}
+	}
+
+	return len(value) >= 3 && strings.Contains(strings.ToLower(l.Network), value)
+}
+
+// Returns the known location name closest to the value, or an empty string if none is close enough.
+func suggestLocationName(probes []globalping.Probe, value string) string {
+	value = strings.ToLower(value)
+
+	if _, err := strconv.Atoi(strings.TrimPrefix(value, "as")); err == nil {
+		return ""
+	}
+
+	best := ""
+	bestDistance := max(1, len(value)/3) + 1
+
+	for _, p := range probes {
+		l := p.Location
+		names := []string{continentNames[l.Continent], l.Region, countryNames[l.Country], usStateNames[l.State], l.City, l.Network}
+
+		for _, name := range append(names, p.Tags...) {
+			if name == "" {
+				continue
+			}
+
+			if d := levenshtein(value, strings.ToLower(name)); d < bestDistance {
+				best = name
+				bestDistance = d
+			}
+		}
+	}
+
+	return best
+}
+
+func levenshtein(a, b string) int {
+	ra, rb := []rune(a), []rune(b)
+	prev := make([]int, len(rb)+1)
+	curr := make([]int, len(rb)+1)
+
+	for j := range prev {
+		prev[j] = j
+	}
+
+	for i := 1; i <= len(ra); i++ {
+		curr[0] = i
+
+		for j := 1; j <= len(rb); j++ {
+			cost := 1
+
+			if ra[i-1] == rb[j-1] {
+				cost = 0
+			}
+
+			curr[j] = min(prev[j]+1, curr[j-1]+1, prev[j-1]+cost)
+		}
+
+		prev, curr = curr, prev
+	}
+
+	return prev[len(rb)]
+}
@@ -0,0 +1,166 @@
+package cmd
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"os"
+	"testing"
+
+	apiMocks "github.com/jsdelivr/globalping-cli/mocks/api"
+	utilsMocks "github.com/jsdelivr/globalping-cli/mocks/utils"
+	viewMocks "github.com/jsdelivr/globalping-cli/mocks/view"
+	"github.com/jsdelivr/globalping-cli/view"
+	"github.com/jsdelivr/globalping-go"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/mock/gomock"
+)
+
+func Test_Location_Check(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	gbMock := apiMocks.NewMockClient(ctrl)
+	gbMock.EXPECT().Probes(t.Context()).Return(createProbesResponse(), nil).Times(1)
+
+	utilsMock := utilsMocks.NewMockUtils(ctrl)
+	utilsMock.EXPECT().Now().Return(defaultCurrentTime).AnyTimes()
+
+	w := new(bytes.Buffer)
+	printer := view.NewPrinter(nil, w, w)
+	_storage := createUncachedTestStorage(t, utilsMock)
+	root := NewRoot(printer, createDefaultContext(), nil, utilsMock, gbMock, nil, _storage)
+
+	os.Args = []string{"globalping", "location", "check", "Germany+Amazon, as24940", "Frankfrut"}
+	err := root.Cmd.ExecuteContext(t.Context())
+	assert.NoError(t, err)
+
+	assert.Equal(t, `Germany+Amazon  1 probe
+  Germany       2 probes
+  Amazon        2 probes
+as24940         1 probe
+Frankfrut       0 probes
+  Frankfrut     0 probes, did you mean Frankfurt?
+`, w.String())
+
+	// The probe list is cached
+	w.Reset()
+	root = NewRoot(printer, createDefaultContext(), nil, utilsMock, gbMock, nil, _storage)
+	os.Args = []string{"globalping", "location", "check", "datacenter-network+VA"}
+	err = root.Cmd.ExecuteContext(t.Context())
+	assert.NoError(t, err)
+
+	assert.Equal(t, `datacenter-network+VA  1 probe
+  datacenter-network   3 probes
+  VA                   1 probe
+`, w.String())
+}
+
+func Test_Execute_Ping_UnknownLocation(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	expectedOpts := createDefaultMeasurementCreate("ping")
+	expectedOpts.Locations = globalping.LocationOptions{{Magic: "Germany"}, {Magic: "Ashbrun"}}
+	expectedResponse := createDefaultMeasurementCreateResponse()
+	expectedMeasurement := createDefaultMeasurement("ping")
+
+	// The cached list may be missing aliases known to the API, so the measurement is still created
+	gbMock := apiMocks.NewMockClient(ctrl)
+	gbMock.EXPECT().CreateMeasurement(t.Context(), expectedOpts).Return(expectedResponse, nil)
+	gbMock.EXPECT().AwaitMeasurement(t.Context(), measurementID1).Return(expectedMeasurement, nil)
+
+	viewerMock := viewMocks.NewMockViewer(ctrl)
+	viewerMock.EXPECT().OutputDefault(measurementID1, expectedMeasurement, expectedOpts)
+
+	utilsMock := utilsMocks.NewMockUtils(ctrl)
+	utilsMock.EXPECT().Now().Return(defaultCurrentTime).AnyTimes()
+
+	w := new(bytes.Buffer)
+	printer := view.NewPrinter(nil, w, w)
+	_storage := createDefaultTestStorage(t, utilsMock)
+	b, _ := json.Marshal(createProbesResponse())
+	assert.NoError(t, _storage.SaveCachedProbes(b))
+	root := NewRoot(printer, createDefaultContext(), viewerMock, utilsMock, gbMock, nil, _storage)
+
+	os.Args = []string{"globalping", "ping", "jsdelivr.com", "from", "Germany,Ashbrun"}
+	err := root.Cmd.ExecuteContext(t.Context())
+	assert.NoError(t, err)
+	assert.Equal(t, "Warning: no probes found in Ashbrun, did you mean Ashburn?\n", w.String())
+}
+
+func Test_Execute_Ping_UnknownLocation_Uncached(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	expectedOpts := createDefaultMeasurementCreate("ping")
+	expectedOpts.Locations = globalping.LocationOptions{{Magic: "Ashbrun"}}
+	expectedResponse := createDefaultMeasurementCreateResponse()
+	expectedMeasurement := createDefaultMeasurement("ping")
+
+	// The probe list is fetched if it's not cached yet, with a deadline so that the measurement isn't delayed for long
+	gbMock := apiMocks.NewMockClient(ctrl)
+	gbMock.EXPECT().Probes(gomock.Any()).DoAndReturn(func(ctx context.Context) (*globalping.ProbesResponse, error) {
+		_, ok := ctx.Deadline()
+		assert.True(t, ok)
+
+		return createProbesResponse(), nil
+	})
+	gbMock.EXPECT().CreateMeasurement(t.Context(), expectedOpts).Return(expectedResponse, nil)
+	gbMock.EXPECT().AwaitMeasurement(t.Context(), measurementID1).Return(expectedMeasurement, nil)
+
+	viewerMock := viewMocks.NewMockViewer(ctrl)
+	viewerMock.EXPECT().OutputDefault(measurementID1, expectedMeasurement, expectedOpts)
+
+	utilsMock := utilsMocks.NewMockUtils(ctrl)
+	utilsMock.EXPECT().Now().Return(defaultCurrentTime).AnyTimes()
+
+	w := new(bytes.Buffer)
+	printer := view.NewPrinter(nil, w, w)
+	_storage := createUncachedTestStorage(t, utilsMock)
+	root := NewRoot(printer, createDefaultContext(), viewerMock, utilsMock, gbMock, nil, _storage)
+
+	os.Args = []string{"globalping", "ping", "jsdelivr.com", "from", "Ashbrun"}
+	err := root.Cmd.ExecuteContext(t.Context())
+	assert.NoError(t, err)
+	assert.Equal(t, "Warning: no probes found in Ashbrun, did you mean Ashburn?\n", w.String())
+
+	b, err := _storage.GetCachedProbes(probesCacheTTL)
+	assert.NoError(t, err)
+	assert.NotEmpty(t, b)
+}
+
+func Test_Execute_Ping_NoProbesFound_Suggestion(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	expectedOpts := createDefaultMeasurementCreate("ping")
+	expectedOpts.Locations = globalping.LocationOptions{{Magic: "Falkenstien"}}
+
+	gbMock := apiMocks.NewMockClient(ctrl)
+	gbMock.EXPECT().CreateMeasurement(t.Context(), expectedOpts).Return(nil, &globalping.MeasurementError{
+		StatusCode: http.StatusUnprocessableEntity,
+		Type:       "no_probes_found",
+		Message:    "No suitable probes found - please try a different location",
+	})
+	// The location check before the measurement fails open if the probe list can't be fetched
+	gomock.InOrder(
+		gbMock.EXPECT().Probes(gomock.Any()).Return(nil, errors.New("connection reset by peer")),
+		gbMock.EXPECT().Probes(t.Context()).Return(createProbesResponse(), nil),
+	)
+
+	utilsMock := utilsMocks.NewMockUtils(ctrl)
+	utilsMock.EXPECT().Now().Return(defaultCurrentTime).AnyTimes()
+
+	w := new(bytes.Buffer)
+	printer := view.NewPrinter(nil, w, w)
+	_storage := createUncachedTestStorage(t, utilsMock)
+	root := NewRoot(printer, createDefaultContext(), nil, utilsMock, gbMock, nil, _storage)
+
+	os.Args = []string{"globalping", "ping", "jsdelivr.com", "from", "Falkenstien"}
+	err := root.Cmd.ExecuteContext(t.Context())
+	assert.Error(t, err)
+	assert.Equal(t, "Error: no_probes_found: No suitable probes found - please try a different location. Did you mean Falkenstein instead of \"Falkenstien\"?\n", w.String())
+}
@@ -4,6 +4,7 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"net/http"
 	"os/signal"
 	"slices"
 	"syscall"
@@ -18,7 +19,7 @@ import (
 func (r *Root) initPing(measurementFlags *pflag.FlagSet, localFlags *pflag.FlagSet) {
 	pingCmd := &cobra.Command{
 		RunE:    r.RunPing,
-		Use:     "ping [target] from [location | measurement ID | @1 | first | @-1 | last | previous]",
+		Use:     "ping [target] from [location | measurement ID | @1 | first | @-1 | last | previous | #tag]",
 		GroupID: "Measurements",
 		Short:   "Perform a ping test",
 		Long: `The ping command checks a target's reachability by sending small data packets. Use it to test network latency and stability, as well as obtain information about packet loss and round-trip times.
@@ -146,12 +147,14 @@ func (r *Root) pingInfinite(ctx context.Context, opts *globalping.MeasurementCre
 	signal.Notify(r.cancel, syscall.SIGINT, syscall.SIGTERM)
 	defer signal.Stop(r.cancel)
 
+	// The hooks are not canceled with the run, so that the hooks of the finished measurements still complete
+	hooks := r.startHookQueue(ctx)
 	done := make(chan struct{})
 	var infiniteTableOutput string
 	var err error
 	go func() {
 		defer close(done)
-		infiniteTableOutput, err = r.ping(runCtx, opts)
+		infiniteTableOutput, err = r.ping(runCtx, opts, hooks)
 	}()
 
 	select {
@@ -165,10 +168,25 @@ func (r *Root) pingInfinite(ctx context.Context, opts *globalping.MeasurementCre
 		}
 	}
 
+	// Reaching the budget stops the run like an interrupt
+	budgetExceeded := errors.Is(err, ErrCreditBudgetExceeded) && r.ctx.MeasurementsCreated > 0
+
+	if budgetExceeded {
+		err = nil
+	}
+
 	if err == nil && !r.ctx.ToLatency {
 		r.viewer.OutputSummary(infiniteTableOutput)
 	}
 
+	hooks.finish(r.cancel, r.printer.ErrWriter)
+
+	if budgetExceeded {
+		r.printer.ErrPrintf("Stopped after spending %d of %d credits.\n", r.ctx.CreditsSpent, r.ctx.MaxCredits)
+	} else if r.ctx.MaxCredits > 0 {
+		r.printer.ErrPrintf("Spent %d of %d credits.\n", r.ctx.CreditsSpent, r.ctx.MaxCredits)
+	}
+
 	if errors.Is(err, view.ErrAllProbesFailed) {
 		r.Cmd.SilenceErrors = true
 	}
@@ -179,7 +197,7 @@ func (r *Root) pingInfinite(ctx context.Context, opts *globalping.MeasurementCre
 	return err
 }
 
-func (r *Root) ping(ctx context.Context, opts *globalping.MeasurementCreate) (string, error) {
+func (r *Root) ping(ctx context.Context, opts *globalping.MeasurementCreate, hooks *hookQueue) (string, error) {
 	var infiniteTableOutput string
 	var runErr error
 	mbuf := NewMeasurementsBuffer(10) // 10 is the maximum number of measurements that can be in progress at the same time
@@ -225,6 +243,7 @@ func (r *Root) ping(ctx context.Context, opts *globalping.MeasurementCreate) (st
 
 			if measurement.Status != globalping.MeasurementStatusInProgress {
 				mbuf.Remove(el)
+				hooks.add(ctx, measurement)
 			} else {
 				el.ProbeStatus = make([]globalping.TestStatus, len(measurement.Results))
 
@@ -285,15 +304,39 @@ func (r *Root) ping(ctx context.Context, opts *globalping.MeasurementCreate) (st
 }
 
 func (r *Root) createMeasurement(ctx context.Context, opts *globalping.MeasurementCreate) (*view.HistoryItem, error) {
-	res, err := r.client.CreateMeasurement(ctx, opts)
+	r.warnUnknownLocations(ctx, opts)
+	err := r.checkCreditBudget(ctx, opts)
+
+	if err != nil {
+		r.Cmd.SilenceUsage = true
+
+		return nil, err
+	}
+
+	res, err := r.createMeasurementWithRetry(ctx, opts)
 
 	if err != nil {
+		var measurementErr *globalping.MeasurementError
+
+		if errors.As(err, &measurementErr) && measurementErr.StatusCode == http.StatusUnprocessableEntity {
+			r.suggestLocation(ctx, opts, measurementErr)
+		}
+
 		r.Cmd.SilenceUsage = silenceUsageOnCreateMeasurementError(err)
 
 		return nil, err
 	}
 
 	r.ctx.MeasurementsCreated++
+
+	if r.ctx.MaxCredits > 0 {
+		if res.ProbesCount > 0 {
+			r.ctx.CreditsSpent += creditsPerProbe(opts) * int64(res.ProbesCount)
+		} else {
+			r.ctx.CreditsSpent += estimateCredits(opts)
+		}
+	}
+
 	hm := &view.HistoryItem{
 		Id:        res.ID,
 		Status:    globalping.MeasurementStatusInProgress,
@@ -0,0 +1,281 @@
+package cmd
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/jsdelivr/globalping-cli/api/probe"
+	"github.com/jsdelivr/globalping-cli/utils"
+	"github.com/spf13/cobra"
+)
+
+func (r *Root) initProbe() {
+	probeCmd := &cobra.Command{
+		Use:   "probe",
+		Short: "Manage the probe running on this machine",
+		Long: `Manage the Globalping probe container installed with the install-probe command.
+The container engine is detected in the same way as by install-probe; with Podman, the commands are run with sudo unless --rootless is used.`,
+	}
+
+	addContainerFlags(probeCmd.PersistentFlags())
+
+	statusCmd := &cobra.Command{
+		RunE:  r.RunProbeStatus,
+		Use:   "status",
+		Short: "Show the state and version of the probe",
+		Long:  `Show the state of the probe container and compare its version with the latest version running in the Globalping network.`,
+		Args:  cobra.NoArgs,
+	}
+
+	logsCmd := &cobra.Command{
+		RunE:  r.RunProbeLogs,
+		Use:   "logs",
+		Short: "Show the recent logs of the probe",
+		Long: `Show the recent logs of the probe container.
+
+Examples:
+  # Show the last 200 lines and keep printing new ones.
+  probe logs --tail 200 --follow`,
+		Args: cobra.NoArgs,
+	}
+
+	logsCmd.Flags().Int("tail", 50, "specify the number of lines to show from the end of the logs")
+	logsCmd.Flags().BoolP("follow", "f", false, "keep printing new lines until stopped (default false)")
+
+	updateCmd := &cobra.Command{
+		RunE:  r.RunProbeUpdate,
+		Use:   "update",
+		Short: "Update the probe to the latest image",
+		Long:  `Pull the latest probe image and, if it has changed, recreate the container with it.`,
+		Args:  cobra.NoArgs,
+	}
+
+	restartCmd := &cobra.Command{
+		RunE:  r.RunProbeRestart,
+		Use:   "restart",
+		Short: "Restart the probe",
+		Long:  `Restart the probe container.`,
+		Args:  cobra.NoArgs,
+	}
+
+	uninstallCmd := &cobra.Command{
+		RunE:  r.RunProbeUninstall,
+		Use:   "uninstall",
+		Short: "Stop and remove the probe",
+		Long:  `Stop and remove the probe container. The image is kept, so that the probe can be reinstalled quickly.`,
+		Args:  cobra.NoArgs,
+	}
+
+	uninstallCmd.Flags().BoolP("yes", "y", false, "remove the probe without asking for confirmation (default false)")
+
+	probeCmd.AddCommand(statusCmd)
+	probeCmd.AddCommand(logsCmd)
+	probeCmd.AddCommand(updateCmd)
+	probeCmd.AddCommand(restartCmd)
+	probeCmd.AddCommand(uninstallCmd)
+
+	r.Cmd.AddCommand(probeCmd)
+}
+
+func (r *Root) RunProbeStatus(cmd *cobra.Command, _ []string) error {
+	cmd.SilenceUsage = true
+	c, status, err := r.probeContainer(cmd)
+
+	if err != nil {
+		return err
+	}
+
+	r.printer.Printf("Container engine: %s\n", c.Engine)
+	r.printer.Printf("State:            %s", status.State)
+
+	if status.State == "running" && !status.StartedAt.IsZero() {
+		r.printer.Printf(", up %s", utils.FormatSeconds(int64(r.utils.Now().Sub(status.StartedAt).Seconds())))
+	}
+
+	r.printer.Printf("\nImage:            %s\n", status.Image)
+
+	if status.Version == "" {
+		r.printer.Println("Version:          unknown")
+
+		return nil
+	}
+
+	r.printer.Printf("Version:          %s", status.Version)
+
+	// The latest version is taken from the online probes, so that no other service needs to be queried
+	probes, err := r.loadProbes(cmd.Context(), true)
+
+	if err != nil {
+		r.printer.Println()
+
+		return nil
+	}
+
+	latest := ""
+
+	for _, p := range probes {
+		if compareVersions(p.Version, latest) > 0 {
+			latest = p.Version
+		}
+	}
+
+	switch {
+	case latest == "":
+		r.printer.Println()
+	case compareVersions(status.Version, latest) < 0:
+		r.printer.Printf(" (latest: %s, run \"globalping probe update\" to update)\n", latest)
+	default:
+		r.printer.Println(" (latest)")
+	}
+
+	return nil
+}
+
+func (r *Root) RunProbeLogs(cmd *cobra.Command, _ []string) error {
+	cmd.SilenceUsage = true
+	tail, _ := cmd.Flags().GetInt("tail")
+	follow, _ := cmd.Flags().GetBool("follow")
+	c, _, err := r.probeContainer(cmd)
+
+	if err != nil {
+		return err
+	}
+
+	return r.probe.ContainerLogs(c, tail, follow, r.printer.OutWriter)
+}
+
+func (r *Root) RunProbeUpdate(cmd *cobra.Command, _ []string) error {
+	cmd.SilenceUsage = true
+	c, status, err := r.probeContainer(cmd)
+
+	if err != nil {
+		return err
+	}
+
+	// The container is recreated with the image and the environment variables it was installed with
+	if status.Image != "" {
+		c.Image = status.Image
+	}
+
+	c.Env = status.Env
+
+	r.printer.Println("Pulling the latest image...")
+	imageID, err := r.probe.PullImage(c)
+
+	if err != nil {
+		return err
+	}
+
+	if strings.TrimPrefix(imageID, "sha256:") == strings.TrimPrefix(status.ImageID, "sha256:") {
+		r.printer.Println("The probe is already up to date.")
+
+		return nil
+	}
+
+	r.printer.Println("Recreating the container with the new image...")
+	err = r.probe.ReplaceContainer(c)
+
+	if err != nil {
+		return err
+	}
+
+	r.printer.Println("The probe was updated successfully.")
+
+	return nil
+}
+
+func (r *Root) RunProbeRestart(cmd *cobra.Command, _ []string) error {
+	cmd.SilenceUsage = true
+	c, _, err := r.probeContainer(cmd)
+
+	if err != nil {
+		return err
+	}
+
+	err = r.probe.RestartContainer(c)
+
+	if err != nil {
+		return err
+	}
+
+	r.printer.Println("The probe was restarted.")
+
+	return nil
+}
+
+func (r *Root) RunProbeUninstall(cmd *cobra.Command, _ []string) error {
+	cmd.SilenceUsage = true
+	yes, _ := cmd.Flags().GetBool("yes")
+	c, _, err := r.probeContainer(cmd)
+
+	if err != nil {
+		return err
+	}
+
+	if !yes && !r.askUser("The probe will stop and the container will be removed. Continue?") {
+		r.printer.Println("Exited without changes.")
+
+		return nil
+	}
+
+	err = r.probe.RemoveContainer(c)
+
+	if err != nil {
+		return err
+	}
+
+	r.printer.Println("The probe was uninstalled. Thank you for being part of our community!")
+
+	return nil
+}
+
+// Returns the container selected by the flags and its status.
+func (r *Root) probeContainer(cmd *cobra.Command) (*probe.Container, *probe.ContainerStatus, error) {
+	c, err := r.containerFromFlags(cmd, true)
+
+	if err != nil {
+		return nil, nil, err
+	}
+
+	status, err := r.probe.ContainerStatus(c)
+
+	if err != nil {
+		if errors.Is(err, probe.ErrContainerNotFound) {
+			return c, nil, fmt.Errorf("%w, run \"globalping install-probe\" to install the probe", err)
+		}
+
+		return c, nil, err
+	}
+
+	return c, status, nil
+}
+
+// Compares versions such as "0.39.1". Missing or invalid parts are treated as 0.
+func compareVersions(a, b string) int {
+	pa := strings.Split(strings.TrimPrefix(a, "v"), ".")
+	pb := strings.Split(strings.TrimPrefix(b, "v"), ".")
+
+	for i := range max(len(pa), len(pb)) {
+		var na, nb int
+
+		if i < len(pa) {
+			na, _ = strconv.Atoi(pa[i])
+		}
+
+		if i < len(pb) {
+			nb, _ = strconv.Atoi(pb[i])
+		}
+
+		if na != nb {
+			if na < nb {
+				return -1
+			}
+
+			return 1
+		}
+	}
+
+	return 0
+}
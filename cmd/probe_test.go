@@ -0,0 +1,262 @@
+package cmd
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/jsdelivr/globalping-cli/api/probe"
+	apiMocks "github.com/jsdelivr/globalping-cli/mocks/api"
+	utilsMocks "github.com/jsdelivr/globalping-cli/mocks/utils"
+	"github.com/jsdelivr/globalping-cli/view"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/mock/gomock"
+)
+
+func Test_Probe_Status(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	probeMock := apiMocks.NewMockProbe(ctrl)
+	probeMock.EXPECT().DetectContainerEngine().Return(probe.ContainerEngineDocker, nil)
+	probeMock.EXPECT().ContainerStatus(probe.NewContainer(probe.ContainerEngineDocker)).Return(createProbeContainerStatus(), nil)
+
+	gbMock := apiMocks.NewMockClient(ctrl)
+	gbMock.EXPECT().Probes(gomock.Any()).Return(createProbesResponse(), nil)
+
+	utilsMock := utilsMocks.NewMockUtils(ctrl)
+	utilsMock.EXPECT().Now().Return(defaultCurrentTime).AnyTimes()
+
+	w := new(bytes.Buffer)
+	printer := view.NewPrinter(nil, w, w)
+	ctx := createDefaultContext()
+	root := NewRoot(printer, ctx, nil, utilsMock, gbMock, probeMock, nil)
+
+	os.Args = []string{"globalping", "probe", "status"}
+	err := root.Cmd.ExecuteContext(t.Context())
+	assert.NoError(t, err)
+
+	assert.Equal(t, `Container engine: Docker
+State:            running, up 3 hours
+Image:            globalping/globalping-probe
+Version:          0.38.0 (latest: 0.39.0, run "globalping probe update" to update)
+`, w.String())
+}
+
+func Test_Probe_Status_Not_Installed(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	probeMock := apiMocks.NewMockProbe(ctrl)
+	probeMock.EXPECT().DetectContainerEngine().Return(probe.ContainerEnginePodman, nil)
+	probeMock.EXPECT().ContainerStatus(probe.NewContainer(probe.ContainerEnginePodman)).Return(nil, fmt.Errorf("%w: globalping-probe", probe.ErrContainerNotFound))
+
+	w := new(bytes.Buffer)
+	printer := view.NewPrinter(nil, w, w)
+	ctx := createDefaultContext()
+	root := NewRoot(printer, ctx, nil, nil, nil, probeMock, nil)
+
+	os.Args = []string{"globalping", "probe", "status"}
+	err := root.Cmd.ExecuteContext(t.Context())
+	assert.ErrorIs(t, err, probe.ErrContainerNotFound)
+
+	assert.Equal(t, "Error: container not found: globalping-probe, run \"globalping install-probe\" to install the probe\n", w.String())
+}
+
+func Test_Probe_Logs(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	w := new(bytes.Buffer)
+	printer := view.NewPrinter(nil, w, w)
+
+	probeMock := apiMocks.NewMockProbe(ctrl)
+	probeMock.EXPECT().DetectContainerEngine().Return(probe.ContainerEngineDocker, nil)
+	probeMock.EXPECT().ContainerStatus(probe.NewContainer(probe.ContainerEngineDocker)).Return(createProbeContainerStatus(), nil)
+	probeMock.EXPECT().ContainerLogs(probe.NewContainer(probe.ContainerEngineDocker), 10, true, w).DoAndReturn(
+		func(_ *probe.Container, _ int, _ bool, out io.Writer) error {
+			_, err := out.Write([]byte("Connected to the API.\n"))
+
+			return err
+		})
+
+	ctx := createDefaultContext()
+	root := NewRoot(printer, ctx, nil, nil, nil, probeMock, nil)
+
+	os.Args = []string{"globalping", "probe", "logs", "--tail", "10", "-f"}
+	err := root.Cmd.ExecuteContext(t.Context())
+	assert.NoError(t, err)
+
+	assert.Equal(t, "Connected to the API.\n", w.String())
+}
+
+func Test_Probe_Update(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	status := createProbeContainerStatus()
+	status.Env = []string{"GP_ADOPTION_TOKEN=token", "GP_HOST_HW=true"}
+
+	// The container is recreated with the environment variables it was installed with
+	expectedContainer := probe.NewContainer(probe.ContainerEngineDocker)
+	expectedContainer.Env = status.Env
+
+	probeMock := apiMocks.NewMockProbe(ctrl)
+	gomock.InOrder(
+		probeMock.EXPECT().DetectContainerEngine().Return(probe.ContainerEngineDocker, nil),
+		probeMock.EXPECT().ContainerStatus(probe.NewContainer(probe.ContainerEngineDocker)).Return(status, nil),
+		probeMock.EXPECT().PullImage(expectedContainer).Return("sha256:new", nil),
+		probeMock.EXPECT().ReplaceContainer(expectedContainer).Return(nil),
+	)
+
+	w := new(bytes.Buffer)
+	printer := view.NewPrinter(nil, w, w)
+	ctx := createDefaultContext()
+	root := NewRoot(printer, ctx, nil, nil, nil, probeMock, nil)
+
+	os.Args = []string{"globalping", "probe", "update"}
+	err := root.Cmd.ExecuteContext(t.Context())
+	assert.NoError(t, err)
+
+	assert.Equal(t, `Pulling the latest image...
+Recreating the container with the new image...
+The probe was updated successfully.
+`, w.String())
+}
+
+func Test_Probe_Update_Failed(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	runErr := &probe.CommandError{Command: "docker run -d --env GP_ADOPTION_TOKEN=[REDACTED] globalping/globalping-probe", Err: errors.New("exit status 125")}
+
+	probeMock := apiMocks.NewMockProbe(ctrl)
+	probeMock.EXPECT().DetectContainerEngine().Return(probe.ContainerEngineDocker, nil)
+	probeMock.EXPECT().ContainerStatus(probe.NewContainer(probe.ContainerEngineDocker)).Return(createProbeContainerStatus(), nil)
+	probeMock.EXPECT().PullImage(probe.NewContainer(probe.ContainerEngineDocker)).Return("sha256:new", nil)
+	probeMock.EXPECT().ReplaceContainer(probe.NewContainer(probe.ContainerEngineDocker)).Return(runErr)
+
+	w := new(bytes.Buffer)
+	printer := view.NewPrinter(nil, w, w)
+	ctx := createDefaultContext()
+	root := NewRoot(printer, ctx, nil, nil, nil, probeMock, nil)
+
+	os.Args = []string{"globalping", "probe", "update"}
+	err := root.Cmd.ExecuteContext(t.Context())
+	assert.ErrorIs(t, err, runErr)
+
+	assert.Equal(t, `Pulling the latest image...
+Recreating the container with the new image...
+Error: docker run -d --env GP_ADOPTION_TOKEN=[REDACTED] globalping/globalping-probe: exit status 125
+`, w.String())
+}
+
+func Test_Probe_Update_Up_To_Date(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	probeMock := apiMocks.NewMockProbe(ctrl)
+	probeMock.EXPECT().DetectContainerEngine().Return(probe.ContainerEnginePodman, nil)
+	probeMock.EXPECT().ContainerStatus(probe.NewContainer(probe.ContainerEnginePodman)).Return(createProbeContainerStatus(), nil)
+	probeMock.EXPECT().PullImage(probe.NewContainer(probe.ContainerEnginePodman)).Return("old", nil)
+
+	w := new(bytes.Buffer)
+	printer := view.NewPrinter(nil, w, w)
+	ctx := createDefaultContext()
+	root := NewRoot(printer, ctx, nil, nil, nil, probeMock, nil)
+
+	os.Args = []string{"globalping", "probe", "update"}
+	err := root.Cmd.ExecuteContext(t.Context())
+	assert.NoError(t, err)
+
+	assert.Equal(t, `Pulling the latest image...
+The probe is already up to date.
+`, w.String())
+}
+
+func Test_Probe_Restart(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	probeMock := apiMocks.NewMockProbe(ctrl)
+	probeMock.EXPECT().DetectContainerEngine().Return(probe.ContainerEngineDocker, nil)
+	probeMock.EXPECT().ContainerStatus(probe.NewContainer(probe.ContainerEngineDocker)).Return(createProbeContainerStatus(), nil)
+	probeMock.EXPECT().RestartContainer(probe.NewContainer(probe.ContainerEngineDocker)).Return(nil)
+
+	w := new(bytes.Buffer)
+	printer := view.NewPrinter(nil, w, w)
+	ctx := createDefaultContext()
+	root := NewRoot(printer, ctx, nil, nil, nil, probeMock, nil)
+
+	os.Args = []string{"globalping", "probe", "restart"}
+	err := root.Cmd.ExecuteContext(t.Context())
+	assert.NoError(t, err)
+
+	assert.Equal(t, "The probe was restarted.\n", w.String())
+}
+
+func Test_Probe_Uninstall(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	probeMock := apiMocks.NewMockProbe(ctrl)
+	probeMock.EXPECT().DetectContainerEngine().Return(probe.ContainerEngineDocker, nil)
+	probeMock.EXPECT().ContainerStatus(probe.NewContainer(probe.ContainerEngineDocker)).Return(createProbeContainerStatus(), nil)
+	probeMock.EXPECT().RemoveContainer(probe.NewContainer(probe.ContainerEngineDocker)).Return(nil)
+
+	reader := bytes.NewReader([]byte("Y\n"))
+	w := new(bytes.Buffer)
+	printer := view.NewPrinter(reader, w, w)
+	ctx := createDefaultContext()
+	root := NewRoot(printer, ctx, nil, nil, nil, probeMock, nil)
+
+	os.Args = []string{"globalping", "probe", "uninstall"}
+	err := root.Cmd.ExecuteContext(t.Context())
+	assert.NoError(t, err)
+
+	assert.Equal(t, `The probe will stop and the container will be removed. Continue? [Y/n] The probe was uninstalled. Thank you for being part of our community!
+`, w.String())
+}
+
+func Test_Probe_Uninstall_Declined(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	probeMock := apiMocks.NewMockProbe(ctrl)
+	probeMock.EXPECT().DetectContainerEngine().Return(probe.ContainerEngineDocker, nil)
+	probeMock.EXPECT().ContainerStatus(probe.NewContainer(probe.ContainerEngineDocker)).Return(createProbeContainerStatus(), nil)
+
+	reader := bytes.NewReader([]byte("n\n"))
+	w := new(bytes.Buffer)
+	printer := view.NewPrinter(reader, w, w)
+	ctx := createDefaultContext()
+	root := NewRoot(printer, ctx, nil, nil, nil, probeMock, nil)
+
+	os.Args = []string{"globalping", "probe", "uninstall"}
+	err := root.Cmd.ExecuteContext(t.Context())
+	assert.NoError(t, err)
+
+	assert.Equal(t, `The probe will stop and the container will be removed. Continue? [Y/n] Exited without changes.
+`, w.String())
+}
+
+func Test_CompareVersions(t *testing.T) {
+	assert.Equal(t, 0, compareVersions("0.39.0", "v0.39.0"))
+	assert.Equal(t, -1, compareVersions("0.9.1", "0.10.0"))
+	assert.Equal(t, 1, compareVersions("1.0", "0.99.99"))
+	assert.Equal(t, 1, compareVersions("0.39.0", ""))
+}
+
+func createProbeContainerStatus() *probe.ContainerStatus {
+	return &probe.ContainerStatus{
+		State:     "running",
+		StartedAt: defaultCurrentTime.Add(-3 * time.Hour),
+		Image:     "globalping/globalping-probe",
+		ImageID:   "sha256:old",
+		Version:   "0.38.0",
+	}
+}
@@ -0,0 +1,315 @@
+package cmd
+
+import (
+	"cmp"
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"slices"
+	"strconv"
+	"strings"
+	"text/tabwriter"
+
+	"github.com/jsdelivr/globalping-cli/utils"
+	"github.com/jsdelivr/globalping-go"
+	"github.com/spf13/cobra"
+)
+
+var ErrInvalidProbeField = errors.New("invalid probe field")
+
+// The fields which can be used with --group-by, in the order of the columns.
+var probeFields = []string{"continent", "region", "country", "state", "city", "asn", "network", "tags", "version"}
+
+type probeGroup struct {
+	Value  string `json:"value"`
+	Probes int    `json:"probes"`
+}
+
+func (r *Root) initProbes() {
+	probesCmd := &cobra.Command{
+		RunE:  r.RunProbes,
+		Use:   "probes",
+		Short: "List the online probes",
+		Long: `List the online probes and their locations, networks, tags, and versions. Use it to check which locations and networks are available before writing a "from" value.
+Filters are case-insensitive and can be combined. The network filter matches a part of the network name.
+
+Examples:
+  # List all probes in Germany.
+  probes --country DE
+
+  # List the probes in the AWS network which are hosted in data centers.
+  probes --asn 16509 --tag datacenter-network
+
+  # Count the probes in each country of Europe.
+  probes --continent EU --group-by country
+
+  # Export all probes to a CSV file.
+  probes --csv > probes.csv`,
+		Args: cobra.NoArgs,
+	}
+
+	flags := probesCmd.Flags()
+	flags.String("continent", "", "only list probes on this continent, e.g. EU")
+	flags.String("region", "", "only list probes in this region, e.g. \"Western Europe\"")
+	flags.String("country", "", "only list probes in this country, e.g. DE")
+	flags.String("state", "", "only list probes in this US state, e.g. TX")
+	flags.String("city", "", "only list probes in this city")
+	flags.Int("asn", 0, "only list probes in this ASN")
+	flags.String("network", "", "only list probes whose network name contains this value")
+	flags.StringArray("tag", nil, "only list probes with this tag; may be repeated to require multiple tags")
+	flags.String("group-by", "", "count the probes for each value of this field: "+strings.Join(probeFields, ", "))
+	flags.BoolP("json", "J", false, "output the probes in JSON format (default false)")
+	flags.Bool("csv", false, "output the probes in CSV format (default false)")
+	probesCmd.MarkFlagsMutuallyExclusive("json", "csv")
+
+	r.Cmd.AddCommand(probesCmd)
+}
+
+func (r *Root) RunProbes(cmd *cobra.Command, _ []string) error {
+	groupBy, _ := cmd.Flags().GetString("group-by")
+	groupBy = strings.ToLower(groupBy)
+
+	if groupBy != "" && !slices.Contains(probeFields, groupBy) {
+		return fmt.Errorf("%w: %s, must be one of %s", ErrInvalidProbeField, groupBy, strings.Join(probeFields, ", "))
+	}
+
+	cmd.SilenceUsage = true
+	res, err := r.client.Probes(cmd.Context())
+
+	if err != nil {
+		return err
+	}
+
+	probes := filterProbes(*res, cmd)
+	slices.SortStableFunc(probes, compareProbes)
+	toJSON, _ := cmd.Flags().GetBool("json")
+	toCSV, _ := cmd.Flags().GetBool("csv")
+
+	if groupBy != "" {
+		groups := groupProbes(probes, groupBy)
+
+		switch {
+		case toJSON:
+			return r.printJSON(groups)
+		case toCSV:
+			rows := make([][]string, 0, len(groups))
+
+			for _, g := range groups {
+				rows = append(rows, []string{g.Value, strconv.Itoa(g.Probes)})
+			}
+
+			return r.printCSV([]string{groupBy, "probes"}, rows)
+		}
+
+		w := tabwriter.NewWriter(r.printer.OutWriter, 0, 0, 2, ' ', 0)
+		_, _ = fmt.Fprintf(w, "%s\tPROBES\n", strings.ToUpper(groupBy))
+
+		for _, g := range groups {
+			_, _ = fmt.Fprintf(w, "%s\t%d\n", g.Value, g.Probes)
+		}
+
+		_ = w.Flush()
+		r.printer.Printf("\n%s in %s\n", utils.Pluralize(int64(len(probes)), "probe"), utils.Pluralize(int64(len(groups)), "group"))
+
+		return nil
+	}
+
+	switch {
+	case toJSON:
+		return r.printJSON(probes)
+	case toCSV:
+		rows := make([][]string, 0, len(probes))
+
+		for _, p := range probes {
+			row := make([]string, 0, len(probeFields))
+
+			for _, field := range probeFields {
+				row = append(row, strings.Join(probeFieldValues(p, field), " "))
+			}
+
+			rows = append(rows, row)
+		}
+
+		return r.printCSV(probeFields, rows)
+	}
+
+	if len(probes) == 0 {
+		r.printer.Println("No probes found")
+
+		return nil
+	}
+
+	w := tabwriter.NewWriter(r.printer.OutWriter, 0, 0, 2, ' ', 0)
+	_, _ = fmt.Fprintln(w, "CONTINENT\tCOUNTRY\tSTATE\tCITY\tASN\tNETWORK\tTAGS\tVERSION")
+
+	for _, p := range probes {
+		_, _ = fmt.Fprintf(w, "%s\t%s\t%s\t%s\t%d\t%s\t%s\t%s\n",
+			p.Location.Continent,
+			p.Location.Country,
+			valueOrDash(p.Location.State),
+			p.Location.City,
+			p.Location.ASN,
+			p.Location.Network,
+			valueOrDash(strings.Join(p.Tags, ", ")),
+			p.Version,
+		)
+	}
+
+	_ = w.Flush()
+	r.printer.Printf("\n%s\n", utils.Pluralize(int64(len(probes)), "probe"))
+
+	return nil
+}
+
+func (r *Root) printJSON(v any) error {
+	b, err := json.MarshalIndent(v, "", "  ")
+
+	if err != nil {
+		return err
+	}
+
+	r.printer.Println(string(b))
+
+	return nil
+}
+
+func (r *Root) printCSV(header []string, rows [][]string) error {
+	w := csv.NewWriter(r.printer.OutWriter)
+	_ = w.Write(header)
+	_ = w.WriteAll(rows)
+
+	return w.Error()
+}
+
+func filterProbes(probes []globalping.Probe, cmd *cobra.Command) []globalping.Probe {
+	continent, _ := cmd.Flags().GetString("continent")
+	region, _ := cmd.Flags().GetString("region")
+	country, _ := cmd.Flags().GetString("country")
+	state, _ := cmd.Flags().GetString("state")
+	city, _ := cmd.Flags().GetString("city")
+	asn, _ := cmd.Flags().GetInt("asn")
+	network, _ := cmd.Flags().GetString("network")
+	tags, _ := cmd.Flags().GetStringArray("tag")
+	network = strings.ToLower(network)
+	filtered := make([]globalping.Probe, 0, len(probes))
+
+	for _, p := range probes {
+		if !matchesFilter(p.Location.Continent, continent) ||
+			!matchesFilter(p.Location.Region, region) ||
+			!matchesFilter(p.Location.Country, country) ||
+			!matchesFilter(p.Location.State, state) ||
+			!matchesFilter(p.Location.City, city) {
+			continue
+		}
+
+		if asn != 0 && p.Location.ASN != asn {
+			continue
+		}
+
+		if network != "" && !strings.Contains(strings.ToLower(p.Location.Network), network) {
+			continue
+		}
+
+		if !hasTags(p, tags) {
+			continue
+		}
+
+		filtered = append(filtered, p)
+	}
+
+	return filtered
+}
+
+func hasTags(p globalping.Probe, tags []string) bool {
+	for _, tag := range tags {
+		if !slices.ContainsFunc(p.Tags, func(t string) bool { return strings.EqualFold(t, tag) }) {
+			return false
+		}
+	}
+
+	return true
+}
+
+func matchesFilter(value string, filter string) bool {
+	return filter == "" || strings.EqualFold(value, filter)
+}
+
+// Returns the probe counts for each value of the field, from the largest.
+// Probes with multiple tags are counted once for each tag.
+func groupProbes(probes []globalping.Probe, field string) []probeGroup {
+	counts := make(map[string]int)
+
+	for _, p := range probes {
+		values := probeFieldValues(p, field)
+
+		if len(values) == 0 {
+			values = []string{"-"}
+		}
+
+		for _, v := range values {
+			counts[v]++
+		}
+	}
+
+	groups := make([]probeGroup, 0, len(counts))
+
+	for value, count := range counts {
+		groups = append(groups, probeGroup{Value: value, Probes: count})
+	}
+
+	slices.SortFunc(groups, func(a, b probeGroup) int {
+		return cmp.Or(cmp.Compare(b.Probes, a.Probes), cmp.Compare(a.Value, b.Value))
+	})
+
+	return groups
+}
+
+func probeFieldValues(p globalping.Probe, field string) []string {
+	var value string
+
+	switch field {
+	case "continent":
+		value = p.Location.Continent
+	case "region":
+		value = p.Location.Region
+	case "country":
+		value = p.Location.Country
+	case "state":
+		value = p.Location.State
+	case "city":
+		value = p.Location.City
+	case "asn":
+		value = strconv.Itoa(p.Location.ASN)
+	case "network":
+		value = p.Location.Network
+	case "tags":
+		return p.Tags
+	case "version":
+		value = p.Version
+	}
+
+	if value == "" {
+		return nil
+	}
+
+	return []string{value}
+}
+
+func compareProbes(a, b globalping.Probe) int {
+	return cmp.Or(
+		cmp.Compare(a.Location.Continent, b.Location.Continent),
+		cmp.Compare(a.Location.Country, b.Location.Country),
+		cmp.Compare(a.Location.State, b.Location.State),
+		cmp.Compare(a.Location.City, b.Location.City),
+		cmp.Compare(a.Location.ASN, b.Location.ASN),
+	)
+}
+
+func valueOrDash(s string) string {
+	if s == "" {
+		return "-"
+	}
+
+	return s
+}
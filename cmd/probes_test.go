@@ -0,0 +1,173 @@
+package cmd
+
+import (
+	"bytes"
+	"os"
+	"testing"
+
+	apiMocks "github.com/jsdelivr/globalping-cli/mocks/api"
+	"github.com/jsdelivr/globalping-cli/view"
+	"github.com/jsdelivr/globalping-go"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/mock/gomock"
+)
+
+func Test_Probes(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	gbMock := apiMocks.NewMockClient(ctrl)
+	gbMock.EXPECT().Probes(t.Context()).Return(createProbesResponse(), nil)
+
+	w := new(bytes.Buffer)
+	printer := view.NewPrinter(nil, w, w)
+	root := NewRoot(printer, createDefaultContext(), nil, nil, gbMock, nil, nil)
+
+	os.Args = []string{"globalping", "probes", "--country", "de", "--tag", "Datacenter-Network"}
+	err := root.Cmd.ExecuteContext(t.Context())
+	assert.NoError(t, err)
+
+	assert.Equal(t, `CONTINENT  COUNTRY  STATE  CITY         ASN    NETWORK              TAGS                                  VERSION
+EU         DE       -      Falkenstein  24940  Hetzner Online GmbH  datacenter-network                    0.39.0
+EU         DE       -      Frankfurt    16509  Amazon.com, Inc.     aws-eu-central-1, datacenter-network  0.39.0
+
+2 probes
+`, w.String())
+}
+
+func Test_Probes_GroupBy(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	gbMock := apiMocks.NewMockClient(ctrl)
+	gbMock.EXPECT().Probes(t.Context()).Return(createProbesResponse(), nil).Times(2)
+
+	w := new(bytes.Buffer)
+	printer := view.NewPrinter(nil, w, w)
+	root := NewRoot(printer, createDefaultContext(), nil, nil, gbMock, nil, nil)
+
+	os.Args = []string{"globalping", "probes", "--group-by", "country"}
+	err := root.Cmd.ExecuteContext(t.Context())
+	assert.NoError(t, err)
+
+	assert.Equal(t, `COUNTRY  PROBES
+DE       2
+US       1
+
+3 probes in 2 groups
+`, w.String())
+
+	w.Reset()
+	root = NewRoot(printer, createDefaultContext(), nil, nil, gbMock, nil, nil)
+	os.Args = []string{"globalping", "probes", "--asn", "16509", "--group-by", "tags", "--csv"}
+	err = root.Cmd.ExecuteContext(t.Context())
+	assert.NoError(t, err)
+
+	assert.Equal(t, `tags,probes
+datacenter-network,2
+aws-eu-central-1,1
+aws-us-east-1,1
+`, w.String())
+}
+
+func Test_Probes_JSON(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	gbMock := apiMocks.NewMockClient(ctrl)
+	gbMock.EXPECT().Probes(t.Context()).Return(createProbesResponse(), nil)
+
+	w := new(bytes.Buffer)
+	printer := view.NewPrinter(nil, w, w)
+	root := NewRoot(printer, createDefaultContext(), nil, nil, gbMock, nil, nil)
+
+	os.Args = []string{"globalping", "probes", "--state", "VA", "--json"}
+	err := root.Cmd.ExecuteContext(t.Context())
+	assert.NoError(t, err)
+
+	assert.Equal(t, `[
+  {
+    "version": "0.38.0",
+    "location": {
+      "continent": "NA",
+      "region": "Northern America",
+      "country": "US",
+      "state": "VA",
+      "city": "Ashburn",
+      "asn": 16509,
+      "network": "Amazon.com, Inc.",
+      "latitude": 39.04,
+      "longitude": -77.49
+    },
+    "tags": [
+      "aws-us-east-1",
+      "datacenter-network"
+    ],
+    "resolvers": [
+      "private"
+    ]
+  }
+]
+`, w.String())
+}
+
+func Test_Probes_InvalidGroupBy(t *testing.T) {
+	w := new(bytes.Buffer)
+	printer := view.NewPrinter(nil, w, w)
+	root := NewRoot(printer, createDefaultContext(), nil, nil, nil, nil, nil)
+
+	os.Args = []string{"globalping", "probes", "--group-by", "probe"}
+	err := root.Cmd.ExecuteContext(t.Context())
+	assert.ErrorIs(t, err, ErrInvalidProbeField)
+}
+
+func createProbesResponse() *globalping.ProbesResponse {
+	return &globalping.ProbesResponse{
+		{
+			Version: "0.39.0",
+			Location: globalping.ProbeLocation{
+				Continent: "EU",
+				Region:    "Western Europe",
+				Country:   "DE",
+				City:      "Frankfurt",
+				ASN:       16509,
+				Network:   "Amazon.com, Inc.",
+				Latitude:  50.11,
+				Longitude: 8.68,
+			},
+			Tags:      []string{"aws-eu-central-1", "datacenter-network"},
+			Resolvers: []string{"private"},
+		},
+		{
+			Version: "0.38.0",
+			Location: globalping.ProbeLocation{
+				Continent: "NA",
+				Region:    "Northern America",
+				Country:   "US",
+				State:     "VA",
+				City:      "Ashburn",
+				ASN:       16509,
+				Network:   "Amazon.com, Inc.",
+				Latitude:  39.04,
+				Longitude: -77.49,
+			},
+			Tags:      []string{"aws-us-east-1", "datacenter-network"},
+			Resolvers: []string{"private"},
+		},
+		{
+			Version: "0.39.0",
+			Location: globalping.ProbeLocation{
+				Continent: "EU",
+				Region:    "Western Europe",
+				Country:   "DE",
+				City:      "Falkenstein",
+				ASN:       24940,
+				Network:   "Hetzner Online GmbH",
+				Latitude:  50.47,
+				Longitude: 12.37,
+			},
+			Tags:      []string{"datacenter-network"},
+			Resolvers: []string{"private"},
+		},
+	}
+}
@@ -0,0 +1,163 @@
+package cmd
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math/rand/v2"
+	"net"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/jsdelivr/globalping-cli/utils"
+	"github.com/jsdelivr/globalping-go"
+)
+
+var (
+	retryBaseDelay = 1 * time.Second
+	retryMaxDelay  = 30 * time.Second
+
+	// The rate limit may report a reset in 0s while the credits are not yet available,
+	// so each wait lasts at least rateLimitMinDelay and the number of waits is capped.
+	rateLimitMinDelay = 1 * time.Second
+	rateLimitMaxWaits = 10
+)
+
+// Creates the measurement, retrying on rate limits and transient errors as configured by the --retry and --wait-for-credits flags.
+func (r *Root) createMeasurementWithRetry(ctx context.Context, opts *globalping.MeasurementCreate) (*globalping.MeasurementCreateResponse, error) {
+	attempt := 0
+	waits := 0
+
+	for {
+		res, err := r.client.CreateMeasurement(ctx, opts)
+
+		if err == nil {
+			return res, nil
+		}
+
+		var delay time.Duration
+		var measurementErr *globalping.MeasurementError
+
+		if errors.As(err, &measurementErr) && measurementErr.StatusCode == http.StatusTooManyRequests {
+			if !r.ctx.WaitForCredits || waits >= rateLimitMaxWaits {
+				return nil, err
+			}
+
+			delay = getRateLimitDelay(measurementErr.Header, waits)
+			waits++
+			r.printer.ErrPrintf("Rate limit reached. Waiting %s for the credits to reset (attempt %d of %d)...\n", formatRetryDelay(delay), waits, rateLimitMaxWaits)
+		} else {
+			reason := getRetryReason(err)
+
+			if reason == "" || attempt >= r.ctx.Retry {
+				return nil, err
+			}
+
+			delay = getRetryDelay(err, attempt)
+			attempt++
+			r.printer.ErrPrintf("Request failed: %s. Retrying in %s (attempt %d of %d)...\n", reason, formatRetryDelay(delay), attempt, r.ctx.Retry)
+		}
+
+		timer := time.NewTimer(delay)
+
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+
+			return nil, ctx.Err()
+		case <-timer.C:
+		}
+	}
+}
+
+// Returns a description of the error if the request can be retried, or an empty string otherwise.
+func getRetryReason(err error) string {
+	var measurementErr *globalping.MeasurementError
+
+	if errors.As(err, &measurementErr) {
+		if measurementErr.StatusCode >= 500 && measurementErr.StatusCode < 600 {
+			return fmt.Sprintf("the API responded with %d %s", measurementErr.StatusCode, http.StatusText(measurementErr.StatusCode))
+		}
+
+		return ""
+	}
+
+	if errors.Is(err, context.Canceled) || errors.Is(err, context.DeadlineExceeded) {
+		return ""
+	}
+
+	var netErr net.Error
+
+	if errors.As(err, &netErr) {
+		return err.Error()
+	}
+
+	return ""
+}
+
+// Returns the delay before the next attempt. Retry-After is used if present, otherwise an exponential backoff with jitter.
+func getRetryDelay(err error, attempt int) time.Duration {
+	var measurementErr *globalping.MeasurementError
+
+	if errors.As(err, &measurementErr) {
+		if d, ok := parseRetryAfter(measurementErr.Header); ok {
+			return d
+		}
+	}
+
+	return getBackoffDelay(attempt)
+}
+
+// Returns the time until the rate limit resets, based on the Retry-After and X-RateLimit-Reset headers.
+// Falls back to the exponential backoff if the reset time is missing or not in the future.
+func getRateLimitDelay(header http.Header, attempt int) time.Duration {
+	if d, ok := parseRetryAfter(header); ok && d > 0 {
+		return max(d, rateLimitMinDelay)
+	}
+
+	reset, err := strconv.ParseInt(header.Get("X-RateLimit-Reset"), 10, 64)
+
+	if err == nil && reset > 0 {
+		return max(time.Duration(reset)*time.Second, rateLimitMinDelay)
+	}
+
+	return max(getBackoffDelay(attempt), rateLimitMinDelay)
+}
+
+// Returns a random delay between half and the full value of min(base * 2^attempt, max).
+func getBackoffDelay(attempt int) time.Duration {
+	d := retryMaxDelay
+
+	if attempt < 30 {
+		d = min(retryBaseDelay<<attempt, retryMaxDelay)
+	}
+
+	return d/2 + rand.N(d/2+1)
+}
+
+func parseRetryAfter(header http.Header) (time.Duration, bool) {
+	value := header.Get("Retry-After")
+
+	if value == "" {
+		return 0, false
+	}
+
+	if seconds, err := strconv.ParseInt(value, 10, 64); err == nil {
+		return time.Duration(max(seconds, 0)) * time.Second, true
+	}
+
+	if t, err := http.ParseTime(value); err == nil {
+		return max(time.Until(t), 0), true
+	}
+
+	return 0, false
+}
+
+func formatRetryDelay(d time.Duration) string {
+	if d < time.Second {
+		return d.Round(time.Millisecond).String()
+	}
+
+	return utils.FormatSeconds(int64(d.Round(time.Second) / time.Second))
+}
@@ -0,0 +1,244 @@
+package cmd
+
+import (
+	"bytes"
+	"net"
+	"net/http"
+	"os"
+	"testing"
+	"time"
+
+	apiMocks "github.com/jsdelivr/globalping-cli/mocks/api"
+	utilsMocks "github.com/jsdelivr/globalping-cli/mocks/utils"
+	viewMocks "github.com/jsdelivr/globalping-cli/mocks/view"
+	"github.com/jsdelivr/globalping-cli/view"
+	"github.com/jsdelivr/globalping-go"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/mock/gomock"
+)
+
+func Test_Execute_Ping_Retry(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	expectedOpts := createDefaultMeasurementCreate("ping")
+	expectedOpts.Locations = globalping.LocationOptions{{Magic: "world"}}
+	expectedResponse := createDefaultMeasurementCreateResponse()
+
+	gbMock := apiMocks.NewMockClient(ctrl)
+	failedCall := gbMock.EXPECT().CreateMeasurement(t.Context(), expectedOpts).Times(1).Return(nil, &globalping.MeasurementError{
+		StatusCode: http.StatusServiceUnavailable,
+		Type:       "service_unavailable",
+		Message:    "service unavailable",
+		Header:     http.Header{"Retry-After": []string{"0"}},
+	})
+	gbMock.EXPECT().CreateMeasurement(t.Context(), expectedOpts).Times(1).Return(expectedResponse, nil).After(failedCall)
+
+	expectedMeasurement := createDefaultMeasurement("ping")
+	gbMock.EXPECT().AwaitMeasurement(t.Context(), expectedResponse.ID).Times(1).Return(expectedMeasurement, nil)
+
+	viewerMock := viewMocks.NewMockViewer(ctrl)
+	viewerMock.EXPECT().OutputDefault(measurementID1, expectedMeasurement, expectedOpts).Times(1)
+
+	utilsMock := utilsMocks.NewMockUtils(ctrl)
+	utilsMock.EXPECT().Now().Return(defaultCurrentTime).AnyTimes()
+
+	w := new(bytes.Buffer)
+	errW := new(bytes.Buffer)
+	printer := view.NewPrinter(nil, w, errW)
+	ctx := createDefaultContext()
+	_storage := createDefaultTestStorage(t, utilsMock)
+	root := NewRoot(printer, ctx, viewerMock, utilsMock, gbMock, nil, _storage)
+
+	os.Args = []string{"globalping", "ping", "jsdelivr.com", "--retry", "2"}
+	err := root.Cmd.ExecuteContext(t.Context())
+	assert.NoError(t, err)
+
+	assert.Equal(t, "", w.String())
+	assert.Equal(t, "Request failed: the API responded with 503 Service Unavailable. Retrying in 0s (attempt 1 of 2)...\n", errW.String())
+	assert.Equal(t, 2, ctx.Retry)
+}
+
+func Test_Execute_Ping_Retry_Exhausted(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	expectedOpts := createDefaultMeasurementCreate("ping")
+	expectedOpts.Locations = globalping.LocationOptions{{Magic: "world"}}
+
+	gbMock := apiMocks.NewMockClient(ctrl)
+	gbMock.EXPECT().CreateMeasurement(t.Context(), expectedOpts).Times(2).Return(nil, &globalping.MeasurementError{
+		StatusCode: http.StatusBadGateway,
+		Type:       "bad_gateway",
+		Message:    "bad gateway",
+		Header:     http.Header{"Retry-After": []string{"0"}},
+	})
+
+	viewerMock := viewMocks.NewMockViewer(ctrl)
+
+	utilsMock := utilsMocks.NewMockUtils(ctrl)
+	utilsMock.EXPECT().Now().Return(defaultCurrentTime).AnyTimes()
+
+	w := new(bytes.Buffer)
+	printer := view.NewPrinter(nil, w, w)
+	ctx := createDefaultContext()
+	_storage := createDefaultTestStorage(t, utilsMock)
+	root := NewRoot(printer, ctx, viewerMock, utilsMock, gbMock, nil, _storage)
+
+	os.Args = []string{"globalping", "ping", "jsdelivr.com", "--retry", "1"}
+	err := root.Cmd.ExecuteContext(t.Context())
+	assert.EqualError(t, err, "bad_gateway: bad gateway")
+
+	assert.Equal(t, "Request failed: the API responded with 502 Bad Gateway. Retrying in 0s (attempt 1 of 1)...\nError: bad_gateway: bad gateway\n", w.String())
+}
+
+func Test_Execute_Ping_WaitForCredits(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	expectedOpts := createDefaultMeasurementCreate("ping")
+	expectedOpts.Locations = globalping.LocationOptions{{Magic: "world"}}
+	expectedResponse := createDefaultMeasurementCreateResponse()
+
+	gbMock := apiMocks.NewMockClient(ctrl)
+	failedCall := gbMock.EXPECT().CreateMeasurement(t.Context(), expectedOpts).Times(2).Return(nil, &globalping.MeasurementError{
+		StatusCode: http.StatusTooManyRequests,
+		Type:       "too_many_requests",
+		Message:    "too many requests",
+		Header:     http.Header{"X-Ratelimit-Reset": []string{"0"}},
+	})
+	gbMock.EXPECT().CreateMeasurement(t.Context(), expectedOpts).Times(1).Return(expectedResponse, nil).After(failedCall)
+
+	expectedMeasurement := createDefaultMeasurement("ping")
+	gbMock.EXPECT().AwaitMeasurement(t.Context(), expectedResponse.ID).Times(1).Return(expectedMeasurement, nil)
+
+	viewerMock := viewMocks.NewMockViewer(ctrl)
+	viewerMock.EXPECT().OutputDefault(measurementID1, expectedMeasurement, expectedOpts).Times(1)
+
+	utilsMock := utilsMocks.NewMockUtils(ctrl)
+	utilsMock.EXPECT().Now().Return(defaultCurrentTime).AnyTimes()
+
+	w := new(bytes.Buffer)
+	errW := new(bytes.Buffer)
+	printer := view.NewPrinter(nil, w, errW)
+	ctx := createDefaultContext()
+	_storage := createDefaultTestStorage(t, utilsMock)
+	root := NewRoot(printer, ctx, viewerMock, utilsMock, gbMock, nil, _storage)
+
+	setRateLimitDelays(t, time.Millisecond, 10)
+
+	// Waiting for credits does not count towards the retries
+	os.Args = []string{"globalping", "ping", "jsdelivr.com", "--wait-for-credits"}
+	err := root.Cmd.ExecuteContext(t.Context())
+	assert.NoError(t, err)
+
+	assert.Equal(t, "", w.String())
+	assert.Equal(t, "Rate limit reached. Waiting 1ms for the credits to reset (attempt 1 of 10)...\nRate limit reached. Waiting 1ms for the credits to reset (attempt 2 of 10)...\n", errW.String())
+}
+
+func Test_Execute_Ping_WaitForCredits_Limit(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	expectedOpts := createDefaultMeasurementCreate("ping")
+	expectedOpts.Locations = globalping.LocationOptions{{Magic: "world"}}
+
+	gbMock := apiMocks.NewMockClient(ctrl)
+	gbMock.EXPECT().CreateMeasurement(t.Context(), expectedOpts).Times(3).Return(nil, &globalping.MeasurementError{
+		StatusCode: http.StatusTooManyRequests,
+		Type:       "too_many_requests",
+		Message:    "too many requests",
+		Header:     http.Header{"X-Ratelimit-Reset": []string{"-1"}},
+	})
+
+	viewerMock := viewMocks.NewMockViewer(ctrl)
+
+	utilsMock := utilsMocks.NewMockUtils(ctrl)
+	utilsMock.EXPECT().Now().Return(defaultCurrentTime).AnyTimes()
+
+	w := new(bytes.Buffer)
+	printer := view.NewPrinter(nil, w, w)
+	ctx := createDefaultContext()
+	_storage := createDefaultTestStorage(t, utilsMock)
+	root := NewRoot(printer, ctx, viewerMock, utilsMock, gbMock, nil, _storage)
+
+	setRateLimitDelays(t, time.Millisecond, 2)
+
+	os.Args = []string{"globalping", "ping", "jsdelivr.com", "--wait-for-credits"}
+	err := root.Cmd.ExecuteContext(t.Context())
+	assert.EqualError(t, err, "too_many_requests: too many requests")
+
+	assert.Equal(t, "Rate limit reached. Waiting 1ms for the credits to reset (attempt 1 of 2)...\nRate limit reached. Waiting 1ms for the credits to reset (attempt 2 of 2)...\nError: too_many_requests: too many requests\n", w.String())
+}
+
+func Test_Execute_Ping_No_Retry(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	expectedOpts := createDefaultMeasurementCreate("ping")
+	expectedOpts.Locations = globalping.LocationOptions{{Magic: "world"}}
+
+	gbMock := apiMocks.NewMockClient(ctrl)
+	gbMock.EXPECT().CreateMeasurement(t.Context(), expectedOpts).Times(1).Return(nil, &globalping.MeasurementError{
+		StatusCode: http.StatusTooManyRequests,
+		Type:       "too_many_requests",
+		Message:    "too many requests",
+		Header:     http.Header{"X-Ratelimit-Reset": []string{"0"}},
+	})
+
+	viewerMock := viewMocks.NewMockViewer(ctrl)
+
+	utilsMock := utilsMocks.NewMockUtils(ctrl)
+	utilsMock.EXPECT().Now().Return(defaultCurrentTime).AnyTimes()
+
+	w := new(bytes.Buffer)
+	printer := view.NewPrinter(nil, w, w)
+	ctx := createDefaultContext()
+	_storage := createDefaultTestStorage(t, utilsMock)
+	root := NewRoot(printer, ctx, viewerMock, utilsMock, gbMock, nil, _storage)
+
+	os.Args = []string{"globalping", "ping", "jsdelivr.com", "--retry", "3"}
+	err := root.Cmd.ExecuteContext(t.Context())
+	assert.EqualError(t, err, "too_many_requests: too many requests")
+}
+
+func Test_getRetryReason(t *testing.T) {
+	assert.Equal(t, "the API responded with 500 Internal Server Error", getRetryReason(&globalping.MeasurementError{StatusCode: http.StatusInternalServerError}))
+	assert.Equal(t, "", getRetryReason(&globalping.MeasurementError{StatusCode: http.StatusBadRequest}))
+	assert.Equal(t, "", getRetryReason(&globalping.MeasurementError{StatusCode: http.StatusTooManyRequests}))
+
+	netErr := &net.OpError{Op: "dial", Net: "tcp", Err: os.ErrDeadlineExceeded}
+	assert.Equal(t, netErr.Error(), getRetryReason(netErr))
+	assert.Equal(t, "", getRetryReason(os.ErrNotExist))
+}
+
+func Test_getBackoffDelay(t *testing.T) {
+	for attempt, expected := range []time.Duration{1 * time.Second, 2 * time.Second, 4 * time.Second, 8 * time.Second, 16 * time.Second, 30 * time.Second, 30 * time.Second} {
+		d := getBackoffDelay(attempt)
+		assert.GreaterOrEqual(t, d, expected/2)
+		assert.LessOrEqual(t, d, expected)
+	}
+
+	assert.LessOrEqual(t, getBackoffDelay(100), retryMaxDelay)
+}
+
+func Test_getRateLimitDelay(t *testing.T) {
+	assert.Equal(t, 120*time.Second, getRateLimitDelay(http.Header{"X-Ratelimit-Reset": []string{"120"}}, 0))
+	assert.Equal(t, 5*time.Second, getRateLimitDelay(http.Header{"X-Ratelimit-Reset": []string{"120"}, "Retry-After": []string{"5"}}, 0))
+
+	assert.Equal(t, rateLimitMinDelay, getRateLimitDelay(http.Header{"Retry-After": []string{"0"}, "X-Ratelimit-Reset": []string{"0"}}, 0))
+
+	d := getRateLimitDelay(http.Header{}, 2)
+	assert.GreaterOrEqual(t, d, 2*retryBaseDelay)
+	assert.LessOrEqual(t, d, 4*retryBaseDelay)
+}
+
+// Disables the backoff and sets the minimum rate limit delay to d, so that the waits are short and predictable.
+func setRateLimitDelays(t *testing.T, d time.Duration, maxWaits int) {
+	baseDelay, minDelay, waits := retryBaseDelay, rateLimitMinDelay, rateLimitMaxWaits
+	retryBaseDelay, rateLimitMinDelay, rateLimitMaxWaits = 0, d, maxWaits
+
+	t.Cleanup(func() {
+		retryBaseDelay, rateLimitMinDelay, rateLimitMaxWaits = baseDelay, minDelay, waits
+	})
+}
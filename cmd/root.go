@@ -1,8 +1,12 @@
 package cmd
 
 import (
+	"fmt"
+	"io"
 	"math"
+	"net/http"
 	"os"
+	"path/filepath"
 	"time"
 
 	"github.com/spf13/pflag"
@@ -12,6 +16,7 @@ import (
 	"github.com/jsdelivr/globalping-cli/api/probe"
 	"github.com/jsdelivr/globalping-cli/storage"
 	"github.com/jsdelivr/globalping-cli/utils"
+	"github.com/jsdelivr/globalping-cli/version"
 	"github.com/jsdelivr/globalping-cli/view"
 	"github.com/jsdelivr/globalping-go"
 	"github.com/spf13/cobra"
@@ -27,6 +32,7 @@ type Root struct {
 	probe   probe.Probe
 	utils   utils.Utils
 	storage *storage.LocalStorage
+	updater *version.Updater
 	Cmd     *cobra.Command
 	cancel  chan os.Signal
 }
@@ -37,19 +43,55 @@ func Execute() {
 	_utils := utils.NewUtils()
 	printer := view.NewPrinter(os.Stdin, os.Stdout, os.Stderr)
 	config := utils.NewConfig()
-	config.Load()
 	localStorage := storage.NewLocalStorage(_utils)
+	// The key is needed to load the config, so it can't be set in the settings file
+	localStorage.SetTokenKey(os.Getenv("GLOBALPING_TOKEN_KEY"), os.Getenv("GLOBALPING_TOKEN_KEY_FILE"))
 
 	if err := localStorage.Init(".globalping-cli"); err != nil {
 		printer.ErrPrintf("Error: failed to initialize storage: %v\n", err)
 		os.Exit(1)
 	}
 
+	for _, err := range localStorage.TokenErrors() {
+		printer.ErrPrintf("Warning: %v\n", err)
+	}
+
 	ctx := &view.Context{
-		APIMinInterval: config.GlobalpingAPIInterval,
-		History:        view.NewHistoryBuffer(10),
-		From:           "world",
-		Limit:          1,
+		History: view.NewHistoryBuffer(10),
+		From:    "world",
+		Limit:   1,
+	}
+
+	// Environment variables take precedence over the settings file
+	userSettings, err := localStorage.LoadSettings()
+
+	if err != nil {
+		printer.ErrPrintf("Warning: failed to load settings: %v\n", err)
+	} else if err := applySettings(userSettings, config, ctx); err != nil {
+		printer.ErrPrintf("Warning: %v\n", err)
+	}
+
+	if err := config.Load(); err != nil {
+		printer.ErrPrintf("Error: %v\n", err)
+		os.Exit(1)
+	}
+
+	parseClientFlags(os.Args[1:], config)
+	ctx.APIMinInterval = config.GlobalpingAPIInterval
+	ctx.Profile = parseProfileFlag(os.Args[1:], config.GlobalpingProfile)
+
+	if ctx.Profile != "" {
+		if err := localStorage.SelectProfile(ctx.Profile); err != nil {
+			printer.ErrPrintf("Error: %v: %s\n", err, ctx.Profile)
+			os.Exit(1)
+		}
+	}
+
+	if config.GlobalpingSession != "" {
+		if err := localStorage.SelectSession(config.GlobalpingSession); err != nil {
+			printer.ErrPrintf("Error: %v: %s\n", err, config.GlobalpingSession)
+			os.Exit(1)
+		}
 	}
 
 	var token *storage.Token
@@ -60,29 +102,119 @@ func Execute() {
 			ExpiresIn:   math.MaxInt64,
 			Expiry:      time.Now().Add(math.MaxInt64),
 		}
+		ctx.TokenFromEnv = true
+	}
+
+	if config.GlobalpingShareURL != "" {
+		utils.ShareURL = config.GlobalpingShareURL
 	}
 
+	if config.GlobalpingRecordDir != "" && config.GlobalpingReplayDir != "" {
+		printer.ErrPrintln("Error: --record and --replay cannot be used together")
+		os.Exit(1)
+	}
+
+	transport, err := api.NewTransport(api.TransportConfig{
+		Proxy:      config.GlobalpingProxy,
+		CACert:     config.GlobalpingCACert,
+		ClientCert: config.GlobalpingClientCert,
+		ClientKey:  config.GlobalpingClientKey,
+	})
+
+	if err != nil {
+		printer.ErrPrintf("Error: %v\n", err)
+		os.Exit(1)
+	}
+
+	// The release archive may take longer than the API timeout to download, so only the response headers have a timeout
+	updaterTransport := transport.Clone()
+	updaterTransport.ResponseHeaderTimeout = 30 * time.Second
+
+	var roundTripper http.RoundTripper = transport
+	var updaterRoundTripper http.RoundTripper = updaterTransport
+	var debugFile *os.File
+
+	if config.GlobalpingDebug || config.GlobalpingDebugBodies || config.GlobalpingDebugFile != "" {
+		debugWriter := printer.ErrWriter
+
+		if config.GlobalpingDebugFile != "" {
+			debugFile, err = os.OpenFile(config.GlobalpingDebugFile, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0600)
+
+			if err != nil {
+				printer.ErrPrintf("Error: failed to open the debug file: %v\n", err)
+				os.Exit(1)
+			}
+
+			debugWriter = debugFile
+		}
+
+		roundTripper = api.NewDebugTransport(transport, debugWriter, config.GlobalpingDebugBodies)
+		// The release archive is binary, so its body is never logged
+		updaterRoundTripper = api.NewDebugTransport(updaterTransport, debugWriter, false)
+	}
+
+	httpClient := &http.Client{
+		Timeout:   30 * time.Second,
+		Transport: roundTripper,
+	}
 	globalpingClient := globalping.NewClient(globalping.Config{
+		HTTPClient:         httpClient,
+		APIURL:             config.GlobalpingAPIURL,
 		UserAgent:          getUserAgent(),
 		CacheExpireSeconds: 30,
 	})
-	apiClient := api.NewClient(api.Config{
-		Utils:            _utils,
-		Storage:          localStorage,
-		Printer:          printer,
-		Globalping:       globalpingClient,
-		AuthToken:        token,
-		AuthClientID:     config.GlobalpingAuthClientID,
-		AuthClientSecret: config.GlobalpingAuthClientSecret,
-	})
+
+	var apiClient api.Client
+
+	if config.GlobalpingReplayDir != "" {
+		apiClient, err = api.NewReplayClient(config.GlobalpingReplayDir)
+
+		if err != nil {
+			printer.ErrPrintf("Error: failed to load the recording: %v\n", err)
+			os.Exit(1)
+		}
+	} else {
+		apiClient = api.NewClient(api.Config{
+			Utils:            _utils,
+			Storage:          localStorage,
+			Printer:          printer,
+			Globalping:       globalpingClient,
+			HTTPClient:       httpClient,
+			AuthToken:        token,
+			AuthURL:          config.GlobalpingAuthURL,
+			DashboardURL:     config.GlobalpingDashboardURL,
+			AuthClientID:     config.GlobalpingAuthClientID,
+			AuthClientSecret: config.GlobalpingAuthClientSecret,
+		})
+	}
+
+	if config.GlobalpingRecordDir != "" {
+		apiClient, err = api.NewRecorder(apiClient, _utils, config.GlobalpingRecordDir)
+
+		if err != nil {
+			printer.ErrPrintf("Error: failed to start recording: %v\n", err)
+			os.Exit(1)
+		}
+	}
 
 	globalpingProbe := probe.NewProbe()
 	viewer := view.NewViewer(ctx, printer, _utils)
 	root := NewRoot(printer, ctx, viewer, _utils, apiClient, globalpingProbe, localStorage)
+	executable, _ := os.Executable()
+
+	if resolved, err := filepath.EvalSymlinks(executable); err == nil {
+		executable = resolved
+	}
+
+	root.updater = version.NewUpdater(&http.Client{Transport: updaterRoundTripper}, config.GlobalpingReleasesURL, executable)
 
-	err := root.Cmd.Execute()
+	err = root.Cmd.Execute()
 	apiClient.Close()
 
+	if debugFile != nil {
+		_ = debugFile.Close()
+	}
+
 	if err != nil {
 		os.Exit(1)
 	}
@@ -129,6 +261,17 @@ For more information about the platform, tips, and best practices, visit our Git
 
 	// Global flags
 	root.Cmd.PersistentFlags().BoolVarP(&ctx.CIMode, "ci", "C", ctx.CIMode, "disable real-time terminal updates and colors, suitable for CI and scripting (default false)")
+	root.Cmd.PersistentFlags().StringVar(&ctx.Profile, "profile", ctx.Profile, "specify the auth profile to use; can also be set with the GLOBALPING_PROFILE environment variable (default is the profile selected with \"auth profiles use\")")
+	root.Cmd.PersistentFlags().String("proxy", "", "send API requests through an HTTP, HTTPS, or SOCKS5 proxy, e.g. socks5://localhost:1080; can also be set with the GLOBALPING_PROXY environment variable (default is the HTTPS_PROXY environment variable)")
+	root.Cmd.PersistentFlags().String("ca-cert", "", "trust the CA certificates in this PEM file in addition to the system ones; can also be set with the GLOBALPING_CA_CERT environment variable")
+	root.Cmd.PersistentFlags().String("client-cert", "", "authenticate to the API with the client certificate in this PEM file; can also be set with the GLOBALPING_CLIENT_CERT environment variable")
+	root.Cmd.PersistentFlags().String("client-key", "", "read the key of the client certificate from this PEM file; can also be set with the GLOBALPING_CLIENT_KEY environment variable (default is the --client-cert file)")
+	root.Cmd.PersistentFlags().Bool("debug", false, "log API requests and responses with secrets redacted to stderr; can also be set with GLOBALPING_DEBUG=1 (default false)")
+	root.Cmd.PersistentFlags().Bool("debug-bodies", false, "include the request and response bodies in the debug log; can also be set with GLOBALPING_DEBUG=bodies (default false)")
+	root.Cmd.PersistentFlags().String("debug-file", "", "append the debug log to this file instead of stderr; can also be set with the GLOBALPING_DEBUG_FILE environment variable")
+	root.Cmd.PersistentFlags().String("record", "", "save the measurement requests and responses to this directory, to be used with --replay")
+	root.Cmd.PersistentFlags().String("replay", "", "serve the measurements recorded with --record from this directory instead of calling the API")
+	root.Cmd.PersistentPreRunE = root.selectProfile
 
 	// Measurement flags
 	measurementFlags := pflag.NewFlagSet("measurements", pflag.ExitOnError)
@@ -136,6 +279,7 @@ For more information about the platform, tips, and best practices, visit our Git
  - names of continents, regions, countries, US states, cities, or networks
  - [@1 | first, @2 ... @-2, @-1 | last | previous] to run with the probes from previous measurements in this session
  - an ID of a previous measurement to run with its probes
+ - [#tag] to run with the probes from the most recent bookmark with this tag
 `)
 	measurementFlags.IntVarP(&ctx.Limit, "limit", "L", ctx.Limit, "define the number of probes to use")
 	measurementFlags.BoolVarP(&ctx.ToJSON, "json", "J", ctx.ToJSON, "output results in JSON format (default false)")
@@ -144,6 +288,11 @@ For more information about the platform, tips, and best practices, visit our Git
 	measurementFlags.BoolVar(&ctx.Share, "share", ctx.Share, "print a link at the end of the results to visualize them online (default false)")
 	measurementFlags.BoolVarP(&ctx.Ipv4, "ipv4", "4", ctx.Ipv4, "resolve names to IPv4 addresses")
 	measurementFlags.BoolVarP(&ctx.Ipv6, "ipv6", "6", ctx.Ipv6, "resolve names to IPv6 addresses")
+	measurementFlags.IntVar(&ctx.Retry, "retry", ctx.Retry, "retry failed requests up to N times on server and connection errors, with exponential backoff")
+	measurementFlags.Int64Var(&ctx.MaxCredits, "max-credits", ctx.MaxCredits, "refuse to create measurements once their estimated cost would exceed N credits; stops continuous measurements when reached (default no limit)")
+	measurementFlags.StringVar(&ctx.OnComplete, "on-complete", ctx.OnComplete, "run this shell command after each measurement which finished without failed tests; the details are passed in the GLOBALPING_MEASUREMENT_* environment variables")
+	measurementFlags.StringVar(&ctx.OnFailure, "on-failure", ctx.OnFailure, "run this shell command after each measurement in which any test failed; the details are passed in the GLOBALPING_MEASUREMENT_* environment variables")
+	measurementFlags.BoolVar(&ctx.WaitForCredits, "wait-for-credits", ctx.WaitForCredits, "wait for the rate limit to reset instead of failing when you run out of credits (default false)")
 
 	root.Cmd.AddGroup(&cobra.Group{ID: "Measurements", Title: "Measurement Commands:"})
 
@@ -161,14 +310,76 @@ For more information about the platform, tips, and best practices, visit our Git
 	root.initPing(measurementFlags, flagGroups["globalping ping"])
 	root.initTraceroute(measurementFlags, flagGroups["globalping traceroute"])
 	root.initInstallProbe()
+	root.initProbe()
 	root.initVersion()
+	root.initUpgrade()
 	root.initHistory()
 	root.initAuth()
 	root.initLimits()
+	root.initProbes()
+	root.initLocation()
+	root.initConfig()
+	root.initSession()
+	root.initBookmark()
+	root.initShare()
+	root.initTUI()
+	root.initCompletions()
 
 	return root
 }
 
+// Applies the --profile flag to the local storage.
+// The flag is also parsed early in Execute so that the API client loads the right token.
+func (r *Root) selectProfile(cmd *cobra.Command, _ []string) error {
+	if r.storage == nil || r.ctx.Profile == "" {
+		return nil
+	}
+
+	err := r.storage.SelectProfile(r.ctx.Profile)
+
+	if err != nil {
+		cmd.SilenceUsage = true
+
+		return fmt.Errorf("%w: %s", err, r.ctx.Profile)
+	}
+
+	return nil
+}
+
+// Returns the value of the --profile flag, ignoring all other flags.
+func parseProfileFlag(args []string, defaultValue string) string {
+	flags := newEarlyFlagSet("profile")
+	profile := flags.String("profile", defaultValue, "")
+	_ = flags.Parse(args)
+
+	return *profile
+}
+
+// Applies the flags used to create the API client to the config, ignoring all other flags.
+func parseClientFlags(args []string, config *utils.Config) {
+	flags := newEarlyFlagSet("client")
+	flags.StringVar(&config.GlobalpingRecordDir, "record", config.GlobalpingRecordDir, "")
+	flags.StringVar(&config.GlobalpingReplayDir, "replay", config.GlobalpingReplayDir, "")
+	flags.BoolVar(&config.GlobalpingDebug, "debug", config.GlobalpingDebug, "")
+	flags.BoolVar(&config.GlobalpingDebugBodies, "debug-bodies", config.GlobalpingDebugBodies, "")
+	flags.StringVar(&config.GlobalpingDebugFile, "debug-file", config.GlobalpingDebugFile, "")
+	flags.StringVar(&config.GlobalpingProxy, "proxy", config.GlobalpingProxy, "")
+	flags.StringVar(&config.GlobalpingCACert, "ca-cert", config.GlobalpingCACert, "")
+	flags.StringVar(&config.GlobalpingClientCert, "client-cert", config.GlobalpingClientCert, "")
+	flags.StringVar(&config.GlobalpingClientKey, "client-key", config.GlobalpingClientKey, "")
+	_ = flags.Parse(args)
+}
+
+// Returns a flag set for flags that are needed before the command is executed. Unknown flags and errors are ignored.
+func newEarlyFlagSet(name string) *pflag.FlagSet {
+	flags := pflag.NewFlagSet(name, pflag.ContinueOnError)
+	flags.ParseErrorsAllowlist.UnknownFlags = true
+	flags.SetOutput(io.Discard)
+	flags.Usage = func() {}
+
+	return flags
+}
+
 // Uses the users terminal size or width of 80 if cannot determine users width
 // Based on https://github.com/spf13/cobra/issues/1805#issuecomment-1246192724
 func wrappedFlagUsages(cmd *pflag.FlagSet) string {
@@ -0,0 +1,215 @@
+package cmd
+
+import (
+	"fmt"
+
+	"github.com/jsdelivr/globalping-cli/utils"
+	"github.com/spf13/cobra"
+)
+
+func (r *Root) initSession() {
+	sessionCmd := &cobra.Command{
+		Use:   "session",
+		Short: "Manage measurement sessions",
+		Long: `Manage measurement sessions. Each terminal has its own session, which stores the measurements used by references such as @1, first, or last.
+You can name a session and switch into it from a different terminal to share these references. Set the GLOBALPING_SESSION environment variable to use a session for a single command.
+
+Examples:
+  # Name the current session.
+  session name incident-4312
+
+  # Use the named session in a different terminal.
+  session use incident-4312
+
+  # Switch back to the session of the current terminal.
+  session use
+
+  # List the measurements of a session.
+  session show incident-4312`,
+	}
+
+	listCmd := &cobra.Command{
+		RunE:  r.RunSessionList,
+		Use:   "list",
+		Short: "List the sessions",
+		Long:  `List the sessions. The current session is marked with an asterisk.`,
+		Args:  cobra.NoArgs,
+	}
+
+	showCmd := &cobra.Command{
+		RunE:  r.RunSessionShow,
+		Use:   "show [session ID | name]",
+		Short: "Display the measurement history of a session",
+		Long:  `Display the measurement history of a session. Defaults to the current session.`,
+		Args:  cobra.MaximumNArgs(1),
+	}
+
+	useCmd := &cobra.Command{
+		RunE:  r.RunSessionUse,
+		Use:   "use [session ID | name]",
+		Short: "Use a session in the current terminal",
+		Long:  `Use a session in the current terminal. Without arguments, the terminal switches back to its own session.`,
+		Args:  cobra.MaximumNArgs(1),
+	}
+
+	clearCmd := &cobra.Command{
+		RunE:  r.RunSessionClear,
+		Use:   "clear [session ID | name]",
+		Short: "Remove the measurements and history of a session",
+		Long:  `Remove the measurements and history of a session. Defaults to the current session.`,
+		Args:  cobra.MaximumNArgs(1),
+	}
+
+	nameCmd := &cobra.Command{
+		RunE:  r.RunSessionName,
+		Use:   "name [name]",
+		Short: "Name the current session",
+		Long:  `Name the current session, so that it can be used from a different terminal.`,
+		Args:  cobra.ExactArgs(1),
+	}
+
+	sessionCmd.AddCommand(listCmd)
+	sessionCmd.AddCommand(showCmd)
+	sessionCmd.AddCommand(useCmd)
+	sessionCmd.AddCommand(clearCmd)
+	sessionCmd.AddCommand(nameCmd)
+
+	r.Cmd.AddCommand(sessionCmd)
+}
+
+func (r *Root) RunSessionList(cmd *cobra.Command, _ []string) error {
+	cmd.SilenceUsage = true
+	sessions, err := r.storage.GetSessions()
+
+	if err != nil {
+		return err
+	}
+
+	if len(sessions) == 0 {
+		r.printer.Println("No sessions found")
+
+		return nil
+	}
+
+	for _, s := range sessions {
+		prefix := "  "
+
+		if s.IsCurrent {
+			prefix = "* "
+		}
+
+		name := s.Name
+
+		if name == "" {
+			name = "-"
+		}
+
+		r.printer.Printf("%s%s | %s | %s | %s\n",
+			prefix,
+			s.ID,
+			name,
+			utils.Pluralize(int64(s.Measurements), "measurement"),
+			s.UpdatedAt.Format("2006-01-02 15:04:05"),
+		)
+	}
+
+	return nil
+}
+
+func (r *Root) RunSessionShow(cmd *cobra.Command, args []string) error {
+	cmd.SilenceUsage = true
+	id, err := r.getSessionIdFromArgs(args)
+
+	if err != nil {
+		return err
+	}
+
+	items, err := r.storage.GetSessionHistory(id, 0)
+
+	if err != nil {
+		return err
+	}
+
+	if len(items) == 0 {
+		r.printer.Println("No history items found")
+
+		return nil
+	}
+
+	for _, item := range items {
+		r.printer.Println(item)
+	}
+
+	return nil
+}
+
+func (r *Root) RunSessionUse(cmd *cobra.Command, args []string) error {
+	cmd.SilenceUsage = true
+	ref := ""
+
+	if len(args) > 0 {
+		ref = args[0]
+	}
+
+	err := r.storage.UseSession(ref)
+
+	if err != nil {
+		return fmt.Errorf("%w: %s", err, ref)
+	}
+
+	if ref == "" {
+		r.printer.Println("Now using the session of the current terminal.")
+
+		return nil
+	}
+
+	r.printer.Printf("Now using session %s.\n", ref)
+
+	return nil
+}
+
+func (r *Root) RunSessionClear(cmd *cobra.Command, args []string) error {
+	cmd.SilenceUsage = true
+	id, err := r.getSessionIdFromArgs(args)
+
+	if err != nil {
+		return err
+	}
+
+	err = r.storage.ClearSession(id)
+
+	if err != nil {
+		return err
+	}
+
+	r.printer.Printf("Session %s cleared.\n", id)
+
+	return nil
+}
+
+func (r *Root) RunSessionName(cmd *cobra.Command, args []string) error {
+	cmd.SilenceUsage = true
+	err := r.storage.NameSession(args[0])
+
+	if err != nil {
+		return fmt.Errorf("%w: %s", err, args[0])
+	}
+
+	r.printer.Printf("Session %s named %s.\n", r.storage.CurrentSessionID(), args[0])
+
+	return nil
+}
+
+func (r *Root) getSessionIdFromArgs(args []string) (string, error) {
+	if len(args) == 0 {
+		return r.storage.CurrentSessionID(), nil
+	}
+
+	id, err := r.storage.FindSession(args[0])
+
+	if err != nil {
+		return "", fmt.Errorf("%w: %s", err, args[0])
+	}
+
+	return id, nil
+}
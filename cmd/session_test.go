@@ -0,0 +1,60 @@
+package cmd
+
+import (
+	"bytes"
+	"os"
+	"testing"
+
+	"github.com/jsdelivr/globalping-cli/view"
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_Session(t *testing.T) {
+	w := new(bytes.Buffer)
+	printer := view.NewPrinter(nil, w, w)
+	ctx := createDefaultContext()
+	_storage := createDefaultTestStorage(t, nil)
+	id := _storage.CurrentSessionID()
+
+	root := NewRoot(printer, ctx, nil, nil, nil, nil, _storage)
+
+	os.Args = []string{"globalping", "session", "name", "incident-4312"}
+	err := root.Cmd.ExecuteContext(t.Context())
+	assert.NoError(t, err)
+	assert.Equal(t, "Session "+id+" named incident-4312.\n", w.String())
+
+	w.Reset()
+	os.Args = []string{"globalping", "session", "show", "incident-4312"}
+	err = root.Cmd.ExecuteContext(t.Context())
+	assert.NoError(t, err)
+	assert.Equal(t, "No history items found\n", w.String())
+
+	assert.NoError(t, _storage.SaveCommandToHistory("1", defaultCurrentTime.Unix(), measurementID1, "ping jsdelivr.com"))
+
+	w.Reset()
+	os.Args = []string{"globalping", "session", "show", "incident-4312"}
+	err = root.Cmd.ExecuteContext(t.Context())
+	assert.NoError(t, err)
+	assert.Equal(t, createDefaultExpectedHistoryItem("1", "ping jsdelivr.com", measurementID1)+"\n", w.String())
+
+	w.Reset()
+	os.Args = []string{"globalping", "session", "use", "unknown"}
+	err = root.Cmd.ExecuteContext(t.Context())
+	assert.EqualError(t, err, "session not found: unknown")
+
+	w.Reset()
+	os.Args = []string{"globalping", "session", "use", "incident-4312"}
+	err = root.Cmd.ExecuteContext(t.Context())
+	assert.NoError(t, err)
+	assert.Equal(t, "Now using session incident-4312.\n", w.String())
+
+	w.Reset()
+	os.Args = []string{"globalping", "session", "clear"}
+	err = root.Cmd.ExecuteContext(t.Context())
+	assert.NoError(t, err)
+	assert.Equal(t, "Session "+id+" cleared.\n", w.String())
+
+	items, err := _storage.GetHistory(0)
+	assert.NoError(t, err)
+	assert.Empty(t, items)
+}
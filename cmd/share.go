@@ -0,0 +1,102 @@
+package cmd
+
+import (
+	"errors"
+	"fmt"
+	"regexp"
+	"strings"
+
+	"github.com/jsdelivr/globalping-cli/utils"
+	"github.com/spf13/cobra"
+)
+
+var (
+	ErrInvalidMeasurementID = errors.New("invalid measurement ID")
+
+	measurementIDRe = regexp.MustCompile(`^[a-zA-Z0-9]{16,64}$`)
+)
+
+func (r *Root) initShare() {
+	shareCmd := &cobra.Command{
+		RunE:  r.RunShare,
+		Use:   "share [measurement ID | @1 | first | @-1 | last | previous | #tag]...",
+		Short: "Print the link to view a measurement online",
+		Long: `Print the link to view the results of a measurement online, e.g. to share it in a chat. Without arguments, the last measurement of this session is shared.
+Multiple measurements are combined into a single link.
+
+Examples:
+  # Print the link to the last measurement and copy it to the clipboard. Copying works over SSH in terminals which support OSC 52.
+  share --copy
+
+  # Open the second measurement of this session in the browser.
+  share @2 --open
+
+  # Share the first and the last measurements together.
+  share first last`,
+	}
+
+	shareCmd.Flags().Bool("open", false, "open the link in the default browser (default false)")
+	shareCmd.Flags().Bool("copy", false, "copy the link to the clipboard (default false)")
+	shareCmd.Flags().Bool("table", false, "display the results in a table format (default false)")
+
+	r.Cmd.AddCommand(shareCmd)
+}
+
+func (r *Root) RunShare(cmd *cobra.Command, args []string) error {
+	cmd.SilenceUsage = true
+	open, _ := cmd.Flags().GetBool("open")
+	copyLink, _ := cmd.Flags().GetBool("copy")
+	table, _ := cmd.Flags().GetBool("table")
+
+	if len(args) == 0 {
+		args = []string{"last"}
+	}
+
+	ids := make([]string, 0, len(args))
+
+	for _, arg := range args {
+		id, err := r.mapFromSession(arg)
+
+		if err != nil {
+			return err
+		}
+
+		if id == "" {
+			if !measurementIDRe.MatchString(arg) {
+				return fmt.Errorf("%w: %s, must be a measurement ID or a session reference such as last or @1", ErrInvalidMeasurementID, arg)
+			}
+
+			id = arg
+		}
+
+		ids = append(ids, id)
+	}
+
+	url := utils.ShareURL + strings.Join(ids, ".")
+
+	if table {
+		url += "&display=table"
+	}
+
+	r.printer.Println(url)
+
+	if copyLink {
+		err := r.printer.CopyToClipboard(url)
+
+		if err != nil {
+			return err
+		}
+
+		r.printer.ErrPrintln("Copied the link to the clipboard.")
+	}
+
+	if open {
+		err := r.utils.OpenBrowser(url)
+
+		if err != nil {
+			return fmt.Errorf("failed to open the browser: %w", err)
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,122 @@
+package cmd
+
+import (
+	"bytes"
+	"encoding/base64"
+	"errors"
+	"os"
+	"testing"
+
+	utilsMocks "github.com/jsdelivr/globalping-cli/mocks/utils"
+	"github.com/jsdelivr/globalping-cli/storage"
+	"github.com/jsdelivr/globalping-cli/view"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/mock/gomock"
+)
+
+func Test_Share(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	w := new(bytes.Buffer)
+	printer := view.NewPrinter(nil, w, w)
+	ctx := createDefaultContext()
+	_storage := createDefaultTestStorage(t, nil)
+	assert.NoError(t, _storage.SaveIdToSession(measurementID1))
+	assert.NoError(t, _storage.SaveIdToSession(measurementID2))
+
+	root := NewRoot(printer, ctx, nil, nil, nil, nil, _storage)
+	os.Args = []string{"globalping", "share"}
+	err := root.Cmd.ExecuteContext(t.Context())
+	assert.NoError(t, err)
+	assert.Equal(t, "https://globalping.io?measurement="+measurementID2+"\n", w.String())
+
+	w.Reset()
+	root = NewRoot(printer, ctx, nil, nil, nil, nil, _storage)
+	os.Args = []string{"globalping", "share", "first", "@2", "--table"}
+	err = root.Cmd.ExecuteContext(t.Context())
+	assert.NoError(t, err)
+	assert.Equal(t, "https://globalping.io?measurement="+measurementID1+"."+measurementID2+"&display=table\n", w.String())
+}
+
+func Test_Share_Open_Copy(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	t.Setenv("TMUX", "")
+	url := "https://globalping.io?measurement=" + measurementID1
+	utilsMock := utilsMocks.NewMockUtils(ctrl)
+	utilsMock.EXPECT().OpenBrowser(url).Return(nil)
+
+	w := new(bytes.Buffer)
+	errW := new(bytes.Buffer)
+	printer := view.NewPrinter(nil, w, errW)
+	printer.SetErrTerminal(true)
+	ctx := createDefaultContext()
+
+	root := NewRoot(printer, ctx, nil, utilsMock, nil, nil, nil)
+	os.Args = []string{"globalping", "share", measurementID1, "--open", "--copy"}
+	err := root.Cmd.ExecuteContext(t.Context())
+	assert.NoError(t, err)
+	assert.Equal(t, url+"\n", w.String())
+	assert.Equal(t, "\033]52;c;"+base64.StdEncoding.EncodeToString([]byte(url))+"\aCopied the link to the clipboard.\n", errW.String())
+}
+
+func Test_Share_Copy_NotTerminal(t *testing.T) {
+	w := new(bytes.Buffer)
+	errW := new(bytes.Buffer)
+	printer := view.NewPrinter(nil, w, errW)
+	ctx := createDefaultContext()
+
+	// The escape sequence would end up in the redirected output
+	root := NewRoot(printer, ctx, nil, nil, nil, nil, nil)
+	os.Args = []string{"globalping", "share", measurementID1, "--copy"}
+	err := root.Cmd.ExecuteContext(t.Context())
+	assert.ErrorIs(t, err, view.ErrClipboardNotTerminal)
+	assert.Equal(t, "https://globalping.io?measurement="+measurementID1+"\n", w.String())
+	assert.Equal(t, "Error: can't copy to the clipboard, stderr is not a terminal\n", errW.String())
+}
+
+func Test_Share_Open_Error(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	openErr := errors.New("unsupported platform")
+	utilsMock := utilsMocks.NewMockUtils(ctrl)
+	utilsMock.EXPECT().OpenBrowser("https://globalping.io?measurement=" + measurementID1).Return(openErr)
+
+	w := new(bytes.Buffer)
+	printer := view.NewPrinter(nil, w, w)
+	ctx := createDefaultContext()
+
+	root := NewRoot(printer, ctx, nil, utilsMock, nil, nil, nil)
+	os.Args = []string{"globalping", "share", measurementID1, "--open"}
+	err := root.Cmd.ExecuteContext(t.Context())
+	assert.ErrorIs(t, err, openErr)
+}
+
+func Test_Share_No_Measurements(t *testing.T) {
+	w := new(bytes.Buffer)
+	printer := view.NewPrinter(nil, w, w)
+	ctx := createDefaultContext()
+	_storage := createDefaultTestStorage(t, nil)
+
+	root := NewRoot(printer, ctx, nil, nil, nil, nil, _storage)
+	os.Args = []string{"globalping", "share", "last"}
+	err := root.Cmd.ExecuteContext(t.Context())
+	assert.ErrorIs(t, err, storage.ErrNoPreviousMeasurements)
+}
+
+func Test_Share_Invalid_ID(t *testing.T) {
+	w := new(bytes.Buffer)
+	printer := view.NewPrinter(nil, w, w)
+	ctx := createDefaultContext()
+	_storage := createDefaultTestStorage(t, nil)
+	assert.NoError(t, _storage.SaveIdToSession(measurementID1))
+
+	root := NewRoot(printer, ctx, nil, nil, nil, nil, _storage)
+	os.Args = []string{"globalping", "share", "lst", "--open"}
+	err := root.Cmd.ExecuteContext(t.Context())
+	assert.ErrorIs(t, err, ErrInvalidMeasurementID)
+	assert.Equal(t, "Error: invalid measurement ID: lst, must be a measurement ID or a session reference such as last or @1\n", w.String())
+}
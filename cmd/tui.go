@@ -0,0 +1,1117 @@
+package cmd
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"github.com/jsdelivr/globalping-cli/utils"
+	"github.com/jsdelivr/globalping-cli/version"
+	"github.com/jsdelivr/globalping-cli/view"
+	"github.com/jsdelivr/globalping-go"
+	"github.com/mattn/go-runewidth"
+	"github.com/spf13/cobra"
+	"github.com/spf13/pflag"
+	"golang.org/x/term"
+)
+
+var (
+	ErrNotTerminal       = errors.New("the TUI requires an interactive terminal")
+	ErrInvalidTUIOptions = errors.New("invalid options")
+	ErrNoTUIMeasurement  = errors.New("no measurement selected")
+	ErrTUICreating       = errors.New("a measurement is already being created")
+)
+
+var tuiMeasurementTypes = []string{"ping", "traceroute", "dns", "mtr", "http"}
+
+type tuiPane int
+
+const (
+	tuiPaneCompose tuiPane = iota
+	tuiPaneResults
+	tuiPaneHistory
+	tuiPaneCount
+)
+
+type tuiView int
+
+const (
+	tuiViewTable tuiView = iota
+	tuiViewRaw
+	tuiViewLatency
+	tuiViewCount
+)
+
+var tuiViewNames = []string{"table", "raw", "latency"}
+
+// The fields of the compose pane
+const (
+	tuiFieldType = iota
+	tuiFieldTarget
+	tuiFieldFrom
+	tuiFieldLimit
+	tuiFieldOptions
+	tuiFieldCount
+)
+
+var tuiFieldNames = []string{"Type", "Target", "From", "Limit", "Options"}
+
+type tuiKeyCode int
+
+const (
+	tuiKeyRune tuiKeyCode = iota
+	tuiKeyEnter
+	tuiKeyTab
+	tuiKeyBackTab
+	tuiKeyBackspace
+	tuiKeyUp
+	tuiKeyDown
+	tuiKeyLeft
+	tuiKeyRight
+	tuiKeyPageUp
+	tuiKeyPageDown
+	tuiKeyQuit
+)
+
+var tuiEscapeKeys = map[string]tuiKeyCode{
+	"\033[A":  tuiKeyUp,
+	"\033[B":  tuiKeyDown,
+	"\033[C":  tuiKeyRight,
+	"\033[D":  tuiKeyLeft,
+	"\033OA":  tuiKeyUp,
+	"\033OB":  tuiKeyDown,
+	"\033OC":  tuiKeyRight,
+	"\033OD":  tuiKeyLeft,
+	"\033[Z":  tuiKeyBackTab,
+	"\033[5~": tuiKeyPageUp,
+	"\033[6~": tuiKeyPageDown,
+}
+
+type tuiKey struct {
+	code tuiKeyCode
+	r    rune
+}
+
+// The state of a measurement polled in the background.
+type tuiUpdate struct {
+	id          string
+	measurement *globalping.Measurement
+	err         error
+}
+
+// The progress or the result of creating a measurement in the background.
+type tuiCreateUpdate struct {
+	progress string // A message printed while creating the measurement, e.g. while waiting for a retry
+	id       string
+	opts     *globalping.MeasurementCreate
+	command  string
+	err      error
+}
+
+type tui struct {
+	root      *Root
+	focus     tuiPane
+	field     int
+	typeIndex int
+	values    []string // Indexed by the field, the type is stored in typeIndex
+
+	view        tuiView
+	id          string
+	measurement *globalping.Measurement
+	opts        *globalping.MeasurementCreate
+	scroll      int
+
+	history  []string // Newest first
+	selected int
+	loading  string // The ID of the history item which is being fetched
+
+	status     string
+	isError    bool
+	warning    string // The last warning printed while creating the measurement, kept after it's created
+	updates    chan *tuiUpdate
+	creates    chan *tuiCreateUpdate
+	stopWatch  context.CancelFunc
+	stopCreate context.CancelFunc
+}
+
+func (r *Root) initTUI() {
+	tuiCmd := &cobra.Command{
+		RunE:  r.RunTUI,
+		Use:   "tui",
+		Short: "Run measurements in an interactive full-screen interface",
+		Long: `Compose and run measurements, watch their results live, and browse the measurement history of your current session in a full-screen interface.
+
+Keys:
+  Tab, Shift+Tab  move between the measurement, results, and history panes
+  Up, Down        select a field or a history item, or scroll the results
+  Left, Right     change the measurement type
+  Enter           run the measurement, or show the selected history item
+  r               rerun the measurement on the same probes
+  v               switch between the table, raw, and latency views
+  o               open the link to the results in the browser
+  c               copy the link to the results to the clipboard
+  q, Ctrl+C       quit
+
+The options field accepts these flags of the measurement commands: --protocol, --port, --packets, --resolver, --trace, --type, --method, --host, --path, --query, --ipv4, and --ipv6.
+
+Examples:
+  # Start the TUI.
+  tui`,
+		Args: cobra.NoArgs,
+	}
+
+	r.Cmd.AddCommand(tuiCmd)
+}
+
+func (r *Root) RunTUI(cmd *cobra.Command, _ []string) error {
+	cmd.SilenceUsage = true
+	in, inOk := r.printer.InReader.(*os.File)
+	out, outOk := r.printer.OutWriter.(*os.File)
+
+	if !inOk || !outOk || !term.IsTerminal(int(in.Fd())) || !term.IsTerminal(int(out.Fd())) {
+		return ErrNotTerminal
+	}
+
+	state, err := term.MakeRaw(int(in.Fd()))
+
+	if err != nil {
+		return fmt.Errorf("failed to set up the terminal: %w", err)
+	}
+
+	defer func() {
+		_ = term.Restore(int(in.Fd()), state)
+	}()
+
+	// Switch to the alternate screen and hide the cursor, so that the previous output is restored on exit
+	r.printer.Print("\033[?1049h\033[?25l")
+	defer r.printer.Print("\033[?25h\033[?1049l")
+
+	ctx := cmd.Context()
+	t := newTUI(r)
+	defer t.stop()
+
+	t.loadHistory()
+	keys := make(chan tuiKey, 16)
+	go readTUIKeys(in, keys)
+
+	// The size is checked periodically, as there is no portable way to get notified about resizing
+	ticker := time.NewTicker(250 * time.Millisecond)
+	defer ticker.Stop()
+
+	width, height := 0, 0
+	redraw := true
+
+	for {
+		w, h := r.printer.GetSize()
+
+		if redraw || w != width || h != height {
+			width, height = w, h
+			r.printer.Print("\033[H" + t.render(width, height))
+			redraw = false
+		}
+
+		select {
+		case <-ctx.Done():
+			return nil
+		case k, ok := <-keys:
+			if !ok || t.handleKey(ctx, k) {
+				return nil
+			}
+
+			redraw = true
+		case u := <-t.updates:
+			t.applyUpdate(u)
+			redraw = true
+		case u := <-t.creates:
+			t.applyCreateUpdate(ctx, u)
+			redraw = true
+		case <-ticker.C:
+		}
+	}
+}
+
+func newTUI(r *Root) *tui {
+	values := make([]string, tuiFieldCount)
+	values[tuiFieldFrom] = r.ctx.From
+	values[tuiFieldLimit] = strconv.Itoa(r.ctx.Limit)
+
+	return &tui{
+		root:    r,
+		field:   tuiFieldTarget,
+		values:  values,
+		updates: make(chan *tuiUpdate),
+		creates: make(chan *tuiCreateUpdate),
+	}
+}
+
+// Handles the key and returns true if the TUI should quit.
+func (t *tui) handleKey(ctx context.Context, k tuiKey) bool {
+	switch k.code {
+	case tuiKeyQuit:
+		return true
+	case tuiKeyTab:
+		t.focus = (t.focus + 1) % tuiPaneCount
+
+		return false
+	case tuiKeyBackTab:
+		t.focus = (t.focus + tuiPaneCount - 1) % tuiPaneCount
+
+		return false
+	}
+
+	if t.focus == tuiPaneCompose {
+		t.handleComposeKey(ctx, k)
+
+		return false
+	}
+
+	if k.code == tuiKeyRune {
+		switch k.r {
+		case 'q':
+			return true
+		case 'r':
+			t.rerun(ctx)
+		case 'v':
+			t.toggleView()
+		case 'o':
+			t.openLink()
+		case 'c':
+			t.copyLink()
+		}
+
+		return false
+	}
+
+	if t.focus == tuiPaneResults {
+		switch k.code {
+		case tuiKeyUp:
+			t.scroll--
+		case tuiKeyDown:
+			t.scroll++
+		case tuiKeyPageUp:
+			t.scroll -= 10
+		case tuiKeyPageDown:
+			t.scroll += 10
+		}
+
+		t.scroll = max(t.scroll, 0)
+
+		return false
+	}
+
+	switch k.code {
+	case tuiKeyUp:
+		t.selected = max(t.selected-1, 0)
+	case tuiKeyDown:
+		t.selected = max(min(t.selected+1, len(t.history)-1), 0)
+	case tuiKeyEnter:
+		t.showHistoryItem(ctx)
+	}
+
+	return false
+}
+
+func (t *tui) handleComposeKey(ctx context.Context, k tuiKey) {
+	switch k.code {
+	case tuiKeyUp:
+		t.field = (t.field + tuiFieldCount - 1) % tuiFieldCount
+	case tuiKeyDown:
+		t.field = (t.field + 1) % tuiFieldCount
+	case tuiKeyLeft, tuiKeyRight:
+		if t.field != tuiFieldType {
+			return
+		}
+
+		delta := 1
+
+		if k.code == tuiKeyLeft {
+			delta = len(tuiMeasurementTypes) - 1
+		}
+
+		t.typeIndex = (t.typeIndex + delta) % len(tuiMeasurementTypes)
+	case tuiKeyEnter:
+		t.run(ctx)
+	case tuiKeyBackspace:
+		if t.field == tuiFieldType {
+			return
+		}
+
+		value := []rune(t.values[t.field])
+
+		if len(value) > 0 {
+			t.values[t.field] = string(value[:len(value)-1])
+		}
+	case tuiKeyRune:
+		if t.field != tuiFieldType {
+			t.values[t.field] += string(k.r)
+		}
+	}
+}
+
+// Creates the measurement from the compose pane.
+func (t *tui) run(ctx context.Context) {
+	if t.stopCreate != nil {
+		t.setError(ErrTUICreating)
+
+		return
+	}
+
+	opts, err := t.measurementCreate()
+
+	if err != nil {
+		t.setError(err)
+
+		return
+	}
+
+	r := t.root
+	r.ctx.From = strings.TrimSpace(t.values[tuiFieldFrom])
+	r.ctx.IsLocationFromSession = false
+	r.ctx.RecordToSession = true
+	opts.Locations, err = r.getLocations()
+
+	if err != nil {
+		t.setError(err)
+
+		return
+	}
+
+	t.create(ctx, opts, t.command())
+}
+
+// Creates the measurement again with the probes of the shown measurement.
+func (t *tui) rerun(ctx context.Context) {
+	if t.id == "" {
+		t.setError(ErrNoTUIMeasurement)
+
+		return
+	}
+
+	if t.stopCreate != nil {
+		t.setError(ErrTUICreating)
+
+		return
+	}
+
+	opts := *t.opts
+	opts.Locations = globalping.PreviousMeasurementID(t.id)
+	t.root.ctx.IsLocationFromSession = true
+	t.root.ctx.RecordToSession = false
+	t.create(ctx, &opts, fmt.Sprintf("%s %s from %s", opts.Type, opts.Target, t.id))
+}
+
+// Creates the measurement in the background, as the credit checks and retries may take a while.
+// The messages printed in the meantime are shown in the status line.
+func (t *tui) create(ctx context.Context, opts *globalping.MeasurementCreate, command string) {
+	ctx, t.stopCreate = context.WithCancel(ctx)
+	t.setStatus("Creating the measurement...")
+	t.warning = ""
+
+	root := *t.root
+	root.printer = view.NewPrinter(nil, io.Discard, &tuiProgressWriter{ctx: ctx, updates: t.creates})
+
+	go func() {
+		hm, err := root.createMeasurement(ctx, opts)
+		u := &tuiCreateUpdate{opts: opts, command: command, err: err}
+
+		if err == nil {
+			u.id = hm.Id
+		}
+
+		select {
+		case t.creates <- u:
+		case <-ctx.Done():
+		}
+	}()
+}
+
+func (t *tui) applyCreateUpdate(ctx context.Context, u *tuiCreateUpdate) {
+	if u.progress != "" {
+		t.setStatus(u.progress)
+
+		if strings.HasPrefix(u.progress, "Warning: ") {
+			t.warning = u.progress
+		}
+
+		return
+	}
+
+	t.stopCreating()
+
+	if u.err != nil {
+		t.setError(u.err)
+
+		return
+	}
+
+	t.setStatus(t.warning)
+	err := t.root.saveToHistory(u.id, u.command)
+
+	if err != nil {
+		t.setError(err)
+	}
+
+	t.loadHistory()
+	t.show(ctx, u.id, u.opts, nil)
+}
+
+// Shows the last measurement of the selected history item. The measurement is fetched in the background
+// and shown by applyUpdate once it arrives, as the request may take a while.
+func (t *tui) showHistoryItem(ctx context.Context) {
+	if t.selected >= len(t.history) {
+		t.setError(ErrNoTUIMeasurement)
+
+		return
+	}
+
+	ids := historyItemIDs(t.history[t.selected])
+
+	if len(ids) == 0 {
+		t.setError(ErrNoTUIMeasurement)
+
+		return
+	}
+
+	t.stopWatching()
+	t.loading = ids[len(ids)-1]
+	t.setStatus("Loading the measurement...")
+	t.watch(ctx, t.loading)
+}
+
+// Shows the history item once its measurement is fetched. The options are restored from the measurement,
+// so that it can be changed and run again from the compose pane.
+func (t *tui) showLoaded(id string, m *globalping.Measurement) {
+	opts := &globalping.MeasurementCreate{
+		Type:              m.Type,
+		Target:            m.Target,
+		Limit:             len(m.Results),
+		Options:           m.Options,
+		InProgressUpdates: true,
+	}
+
+	if opts.Options == nil {
+		opts.Options = &globalping.MeasurementOptions{}
+	}
+
+	if m.Type == "http" && opts.Options.Request == nil {
+		opts.Options.Request = &globalping.RequestOptions{}
+	}
+
+	if i := slices.Index(tuiMeasurementTypes, string(m.Type)); i >= 0 {
+		t.typeIndex = i
+	}
+
+	t.values[tuiFieldTarget] = m.Target
+	t.setStatus("")
+	t.display(id, opts, m)
+}
+
+func (t *tui) show(ctx context.Context, id string, opts *globalping.MeasurementCreate, m *globalping.Measurement) {
+	t.stopWatching()
+	t.display(id, opts, m)
+
+	if m == nil || m.Status == globalping.MeasurementStatusInProgress {
+		t.watch(ctx, id)
+	}
+}
+
+func (t *tui) display(id string, opts *globalping.MeasurementCreate, m *globalping.Measurement) {
+	t.loading = ""
+	t.id = id
+	t.opts = opts
+	t.measurement = m
+	t.scroll = 0
+
+	if t.view == tuiViewLatency && !hasLatencyView(string(opts.Type)) {
+		t.view = tuiViewTable
+	}
+}
+
+// Polls the measurement in the background until it's finished.
+func (t *tui) watch(ctx context.Context, id string) {
+	ctx, t.stopWatch = context.WithCancel(ctx)
+
+	go func() {
+		for {
+			m, err := t.root.client.GetMeasurement(ctx, id)
+
+			select {
+			case t.updates <- &tuiUpdate{id: id, measurement: m, err: err}:
+			case <-ctx.Done():
+				return
+			}
+
+			if err != nil || m.Status != globalping.MeasurementStatusInProgress {
+				return
+			}
+
+			timer := time.NewTimer(t.root.ctx.APIMinInterval)
+
+			select {
+			case <-ctx.Done():
+				timer.Stop()
+
+				return
+			case <-timer.C:
+			}
+		}
+	}()
+}
+
+// Stops all background tasks.
+func (t *tui) stop() {
+	t.stopWatching()
+	t.stopCreating()
+}
+
+func (t *tui) stopWatching() {
+	if t.stopWatch != nil {
+		t.stopWatch()
+		t.stopWatch = nil
+	}
+}
+
+func (t *tui) stopCreating() {
+	if t.stopCreate != nil {
+		t.stopCreate()
+		t.stopCreate = nil
+	}
+}
+
+func (t *tui) applyUpdate(u *tuiUpdate) {
+	// Updates of measurements which are no longer shown are ignored
+	if u.id != t.id && u.id != t.loading {
+		return
+	}
+
+	if u.err != nil {
+		t.loading = ""
+		t.setError(u.err)
+
+		return
+	}
+
+	// The watch continues for the loaded history item if it's still in progress
+	if u.id == t.loading {
+		t.showLoaded(u.id, u.measurement)
+
+		return
+	}
+
+	t.measurement = u.measurement
+}
+
+func (t *tui) toggleView() {
+	t.view = (t.view + 1) % tuiViewCount
+
+	if t.view == tuiViewLatency && !hasLatencyView(t.measurementType()) {
+		t.view = tuiViewTable
+	}
+
+	t.scroll = 0
+}
+
+func (t *tui) openLink() {
+	if t.id == "" {
+		t.setError(ErrNoTUIMeasurement)
+
+		return
+	}
+
+	err := t.root.utils.OpenBrowser(utils.ShareURL + t.id)
+
+	if err != nil {
+		t.setError(fmt.Errorf("failed to open the browser: %w", err))
+
+		return
+	}
+
+	t.setStatus("Opened the link in the browser.")
+}
+
+func (t *tui) copyLink() {
+	if t.id == "" {
+		t.setError(ErrNoTUIMeasurement)
+
+		return
+	}
+
+	err := t.root.printer.CopyToClipboard(utils.ShareURL + t.id)
+
+	if err != nil {
+		t.setError(err)
+
+		return
+	}
+
+	t.setStatus("Copied the link to the clipboard.")
+}
+
+func (t *tui) loadHistory() {
+	items, err := t.root.storage.GetHistory(0)
+
+	if err != nil {
+		t.setError(err)
+
+		return
+	}
+
+	slices.Reverse(items)
+	t.history = items
+	t.selected = 0
+}
+
+func (t *tui) setStatus(s string) {
+	t.status = s
+	t.isError = false
+}
+
+func (t *tui) setError(err error) {
+	t.status = err.Error()
+	t.isError = true
+}
+
+func (t *tui) measurementType() string {
+	if t.opts != nil {
+		return string(t.opts.Type)
+	}
+
+	return tuiMeasurementTypes[t.typeIndex]
+}
+
+// Returns the measurement composed in the compose pane, without the locations.
+func (t *tui) measurementCreate() (*globalping.MeasurementCreate, error) {
+	target := strings.TrimSpace(t.values[tuiFieldTarget])
+
+	if target == "" {
+		return nil, errors.New("provided target is empty")
+	}
+
+	limit, err := strconv.Atoi(strings.TrimSpace(t.values[tuiFieldLimit]))
+
+	if err != nil || limit < 1 {
+		return nil, errors.New("limit must be at least 1")
+	}
+
+	opts := &globalping.MeasurementCreate{
+		Type:              globalping.MeasurementType(tuiMeasurementTypes[t.typeIndex]),
+		Target:            target,
+		Limit:             limit,
+		InProgressUpdates: true,
+		Options:           &globalping.MeasurementOptions{},
+	}
+
+	err = parseTUIOptions(opts, t.values[tuiFieldOptions])
+
+	if err != nil {
+		return nil, err
+	}
+
+	return opts, nil
+}
+
+// Returns the command equivalent to the composed measurement, which is saved to the history.
+func (t *tui) command() string {
+	parts := []string{tuiMeasurementTypes[t.typeIndex], strings.TrimSpace(t.values[tuiFieldTarget])}
+
+	if from := strings.TrimSpace(t.values[tuiFieldFrom]); from != "" {
+		parts = append(parts, "from", from)
+	}
+
+	if limit := strings.TrimSpace(t.values[tuiFieldLimit]); limit != "1" {
+		parts = append(parts, "--limit", limit)
+	}
+
+	if options := strings.TrimSpace(t.values[tuiFieldOptions]); options != "" {
+		parts = append(parts, options)
+	}
+
+	return strings.Join(parts, " ")
+}
+
+// Parses the options field, which accepts a subset of the flags of the measurement commands.
+func parseTUIOptions(opts *globalping.MeasurementCreate, s string) error {
+	o := opts.Options
+	request := &globalping.RequestOptions{}
+	flags := pflag.NewFlagSet("options", pflag.ContinueOnError)
+	flags.SetOutput(io.Discard)
+	flags.StringVar(&o.Protocol, "protocol", "", "")
+	flags.Uint16Var(&o.Port, "port", 0, "")
+	flags.IntVar(&o.Packets, "packets", 0, "")
+	flags.StringVar(&o.Resolver, "resolver", "", "")
+	flags.BoolVar(&o.Trace, "trace", false, "")
+	queryType := flags.String("type", "", "")
+	flags.StringVarP(&request.Method, "method", "X", "", "")
+	flags.StringVar(&request.Host, "host", "", "")
+	flags.StringVar(&request.Path, "path", "", "")
+	flags.StringVar(&request.Query, "query", "", "")
+	ipv4 := flags.BoolP("ipv4", "4", false, "")
+	ipv6 := flags.BoolP("ipv6", "6", false, "")
+
+	err := flags.Parse(strings.Fields(s))
+
+	if err != nil {
+		return fmt.Errorf("%w: %v", ErrInvalidTUIOptions, err)
+	}
+
+	if flags.NArg() > 0 {
+		return fmt.Errorf("%w: unexpected argument %s", ErrInvalidTUIOptions, flags.Arg(0))
+	}
+
+	o.Protocol = strings.ToUpper(o.Protocol)
+
+	if *ipv4 {
+		o.IPVersion = globalping.IPVersion4
+	} else if *ipv6 {
+		o.IPVersion = globalping.IPVersion6
+	}
+
+	switch opts.Type {
+	case "dns":
+		if *queryType != "" {
+			o.Query = &globalping.QueryOptions{Type: strings.ToUpper(*queryType)}
+		}
+	case "http":
+		request.Method = strings.ToUpper(request.Method)
+
+		if request.Method == "" {
+			request.Method = "HEAD"
+		}
+
+		o.Request = request
+	}
+
+	return nil
+}
+
+// Returns the measurement IDs of a history item, which ends with the link to the measurements.
+func historyItemIDs(item string) []string {
+	_, link, ok := strings.Cut(item, "\n> ")
+
+	if !ok {
+		return nil
+	}
+
+	return strings.Split(strings.TrimPrefix(link, utils.ShareURL), ".")
+}
+
+func hasLatencyView(measurementType string) bool {
+	return measurementType == "ping" || measurementType == "dns" || measurementType == "http"
+}
+
+func (t *tui) render(width, height int) string {
+	p := t.root.printer
+
+	if width < 60 || height < 12 {
+		return fitTUILine("The terminal is too small, resize it or press Ctrl+C to quit.", width) + "\033[J"
+	}
+
+	sidebarWidth := min(44, width/3)
+	resultsWidth := width - sidebarWidth - 3
+	bodyHeight := height - 3
+	sidebar := t.renderSidebar(sidebarWidth, bodyHeight)
+	results := t.renderResults(resultsWidth, bodyHeight)
+
+	lines := make([]string, 0, height)
+	lines = append(lines, p.Bold(fitTUILine("Globalping CLI v"+version.Version, width)))
+
+	for i := range bodyHeight {
+		lines = append(lines, sidebar[i]+" | "+results[i])
+	}
+
+	status := fitTUILine(t.status, width)
+
+	if t.isError {
+		status = p.Color(status, view.FGRed)
+	}
+
+	lines = append(lines, status, p.Color(fitTUILine(t.keysHelp(), width), view.FGBrightBlack))
+
+	// Each line clears the rest of the previous screen, and the last line doesn't end with a new line to avoid scrolling
+	return strings.Join(lines, "\033[K\r\n") + "\033[K"
+}
+
+func (t *tui) renderSidebar(width, height int) []string {
+	p := t.root.printer
+	lines := make([]string, 0, height)
+	lines = append(lines, t.renderTitle("Measurement", width, t.focus == tuiPaneCompose))
+
+	for i, name := range tuiFieldNames {
+		value := t.values[i]
+
+		if i == tuiFieldType {
+			value = "< " + tuiMeasurementTypes[t.typeIndex] + " >"
+		} else if t.focus == tuiPaneCompose && i == t.field {
+			value += "_"
+		}
+
+		lines = append(lines, t.renderItem(fmt.Sprintf("%-9s%s", name+":", value), width, i == t.field, t.focus == tuiPaneCompose))
+	}
+
+	lines = append(lines, fitTUILine("", width), t.renderTitle("History", width, t.focus == tuiPaneHistory))
+	rows := height - len(lines)
+
+	if len(t.history) == 0 {
+		lines = append(lines, p.Color(fitTUILine("No history items found", width), view.FGBrightBlack))
+	}
+
+	// Keep the selected item visible
+	offset := max(t.selected-rows+1, 0)
+
+	for i := offset; i < len(t.history) && len(lines) < height; i++ {
+		lines = append(lines, t.renderItem(historyItemSummary(t.history[i]), width, i == t.selected, t.focus == tuiPaneHistory))
+	}
+
+	for len(lines) < height {
+		lines = append(lines, fitTUILine("", width))
+	}
+
+	return lines
+}
+
+func (t *tui) renderResults(width, height int) []string {
+	title := "Results: " + tuiViewNames[t.view] + " view"
+
+	if t.id != "" {
+		title += " | " + t.id
+
+		if t.measurement != nil {
+			title += " | " + string(t.measurement.Status)
+		}
+	}
+
+	lines := []string{t.renderTitle(title, width, t.focus == tuiPaneResults)}
+	var content []string
+
+	switch {
+	case t.id == "":
+		content = []string{"Compose a measurement and press Enter to run it, or select one from the history."}
+	case t.measurement == nil:
+		content = []string{"Waiting for the results..."}
+	default:
+		content = strings.Split(strings.TrimRight(t.renderResultsOutput(width, height-1), "\n"), "\n")
+	}
+
+	t.scroll = max(min(t.scroll, len(content)-(height-1)), 0)
+
+	for i := t.scroll; i < len(content) && len(lines) < height; i++ {
+		lines = append(lines, fitTUILine(content[i], width))
+	}
+
+	for len(lines) < height {
+		lines = append(lines, "")
+	}
+
+	return lines
+}
+
+// Renders the results with the same viewers as the measurement commands.
+func (t *tui) renderResultsOutput(width, height int) string {
+	b := new(bytes.Buffer)
+	printer := view.NewPrinter(nil, b, b)
+	printer.SetSize(width, height)
+	printer.DisableStyling()
+
+	ctx := &view.Context{
+		Cmd:     string(t.opts.Type),
+		Table:   t.view == tuiViewTable,
+		History: view.NewHistoryBuffer(1),
+	}
+	viewer := view.NewViewer(ctx, printer, t.root.utils)
+	var err error
+
+	switch t.view {
+	case tuiViewTable:
+		_, err = viewer.OutputTable(t.measurement)
+
+		if errors.Is(err, view.ErrAllProbesFailed) {
+			err = nil
+		}
+	case tuiViewRaw:
+		viewer.OutputDefault(t.id, t.measurement, t.opts)
+	case tuiViewLatency:
+		err = viewer.OutputLatency(t.id, t.measurement)
+	}
+
+	if err != nil {
+		return err.Error()
+	}
+
+	return b.String()
+}
+
+func (t *tui) renderTitle(s string, width int, focused bool) string {
+	if focused {
+		return t.root.printer.BoldColor(fitTUILine(s, width), view.FGCyan)
+	}
+
+	return t.root.printer.Bold(fitTUILine(s, width))
+}
+
+func (t *tui) renderItem(s string, width int, selected bool, focused bool) string {
+	if !selected {
+		return fitTUILine("  "+s, width)
+	}
+
+	line := fitTUILine("> "+s, width)
+
+	if focused {
+		return t.root.printer.Color(line, view.BGBlue)
+	}
+
+	return line
+}
+
+func (t *tui) keysHelp() string {
+	switch t.focus {
+	case tuiPaneCompose:
+		return "Tab: next pane | Up/Down: field | Left/Right: type | Enter: run | Ctrl+C: quit"
+	case tuiPaneResults:
+		return "Tab: next pane | Up/Down: scroll | r: rerun | v: view | o: open link | c: copy link | q: quit"
+	default:
+		return "Tab: next pane | Up/Down: select | Enter: show | r: rerun | v: view | o: open link | q: quit"
+	}
+}
+
+// Returns the index and the command of a history item.
+func historyItemSummary(item string) string {
+	line, _, _ := strings.Cut(item, "\n")
+	parts := strings.SplitN(line, " | ", 3)
+
+	if len(parts) < 3 {
+		return line
+	}
+
+	return fmt.Sprintf("%-3s %s", parts[0], parts[2])
+}
+
+// Truncates or pads the line to the width. Tabs are expanded and carriage returns removed, as they would break the layout.
+func fitTUILine(s string, width int) string {
+	s = strings.ReplaceAll(s, "\r", "")
+
+	if strings.Contains(s, "\t") {
+		b := strings.Builder{}
+		column := 0
+
+		for _, c := range s {
+			if c == '\t' {
+				n := 8 - column%8
+				b.WriteString(strings.Repeat(" ", n))
+				column += n
+
+				continue
+			}
+
+			b.WriteRune(c)
+			column += runewidth.RuneWidth(c)
+		}
+
+		s = b.String()
+	}
+
+	return runewidth.FillRight(runewidth.Truncate(s, width, ""), width)
+}
+
+// Sends each line written to it as a progress update of the measurement being created.
+type tuiProgressWriter struct {
+	ctx     context.Context
+	updates chan<- *tuiCreateUpdate
+}
+
+func (w *tuiProgressWriter) Write(b []byte) (int, error) {
+	for _, line := range strings.Split(string(b), "\n") {
+		line = strings.TrimSpace(line)
+
+		if line == "" {
+			continue
+		}
+
+		select {
+		case w.updates <- &tuiCreateUpdate{progress: line}:
+		case <-w.ctx.Done():
+			return 0, w.ctx.Err()
+		}
+	}
+
+	return len(b), nil
+}
+
+// Reads the keys until the input is closed.
+func readTUIKeys(in io.Reader, keys chan<- tuiKey) {
+	defer close(keys)
+
+	b := make([]byte, 64)
+
+	for {
+		n, err := in.Read(b)
+
+		if err != nil {
+			return
+		}
+
+		for _, k := range parseTUIKeys(b[:n]) {
+			keys <- k
+		}
+	}
+}
+
+// Parses the input of a terminal in raw mode. Unknown escape sequences and control characters are ignored.
+func parseTUIKeys(b []byte) []tuiKey {
+	keys := make([]tuiKey, 0, len(b))
+
+	for len(b) > 0 {
+		if b[0] == 0x1b {
+			n := 1
+
+			if len(b) > 1 && (b[1] == '[' || b[1] == 'O') {
+				n = 2
+
+				// The sequence ends with a byte in the 0x40-0x7e range
+				for n < len(b) && (b[n] < 0x40 || b[n] > 0x7e) {
+					n++
+				}
+
+				n = min(n+1, len(b))
+			}
+
+			if code, ok := tuiEscapeKeys[string(b[:n])]; ok {
+				keys = append(keys, tuiKey{code: code})
+			}
+
+			b = b[n:]
+
+			continue
+		}
+
+		switch b[0] {
+		case '\r', '\n':
+			keys = append(keys, tuiKey{code: tuiKeyEnter})
+		case '\t':
+			keys = append(keys, tuiKey{code: tuiKeyTab})
+		case 0x7f, 0x08:
+			keys = append(keys, tuiKey{code: tuiKeyBackspace})
+		case 0x03:
+			keys = append(keys, tuiKey{code: tuiKeyQuit})
+		}
+
+		if b[0] < 0x20 || b[0] == 0x7f {
+			b = b[1:]
+
+			continue
+		}
+
+		r, n := utf8.DecodeRune(b)
+		keys = append(keys, tuiKey{code: tuiKeyRune, r: r})
+		b = b[n:]
+	}
+
+	return keys
+}
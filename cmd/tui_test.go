@@ -0,0 +1,312 @@
+package cmd
+
+import (
+	"bytes"
+	"net/http"
+	"strings"
+	"testing"
+
+	apiMocks "github.com/jsdelivr/globalping-cli/mocks/api"
+	utilsMocks "github.com/jsdelivr/globalping-cli/mocks/utils"
+	"github.com/jsdelivr/globalping-cli/utils"
+	"github.com/jsdelivr/globalping-cli/version"
+	"github.com/jsdelivr/globalping-cli/view"
+	"github.com/jsdelivr/globalping-go"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/mock/gomock"
+)
+
+func Test_TUI_Run(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	expectedOpts := &globalping.MeasurementCreate{
+		Type:              "dns",
+		Target:            "jsdelivr.com",
+		Limit:             2,
+		Locations:         globalping.LocationOptions{{Magic: "world"}},
+		InProgressUpdates: true,
+		Options: &globalping.MeasurementOptions{
+			Resolver: "1.1.1.1",
+			Query:    &globalping.QueryOptions{Type: "MX"},
+		},
+	}
+	expectedMeasurement := createDefaultMeasurement("dns")
+
+	gbMock := apiMocks.NewMockClient(ctrl)
+	gbMock.EXPECT().CreateMeasurement(gomock.Any(), expectedOpts).Return(createDefaultMeasurementCreateResponse(), nil)
+	gbMock.EXPECT().GetMeasurement(gomock.Any(), measurementID1).Return(expectedMeasurement, nil)
+
+	utilsMock := utilsMocks.NewMockUtils(ctrl)
+	utilsMock.EXPECT().Now().Return(defaultCurrentTime).AnyTimes()
+
+	_storage := createDefaultTestStorage(t, utilsMock)
+	assert.NoError(t, _storage.SaveCachedProbes([]byte(`[]`)))
+
+	printer := view.NewPrinter(nil, new(bytes.Buffer), new(bytes.Buffer))
+	printer.DisableStyling()
+	root := NewRoot(printer, createDefaultContext(), nil, utilsMock, gbMock, nil, _storage)
+
+	tui := newTUI(root)
+	defer tui.stop()
+
+	keys := []tuiKey{{code: tuiKeyUp}, {code: tuiKeyRight}, {code: tuiKeyRight}, {code: tuiKeyDown}}
+	keys = append(keys, typeTUIKeys("jsdelivr.com")...)
+	keys = append(keys, tuiKey{code: tuiKeyDown}, tuiKey{code: tuiKeyDown}, tuiKey{code: tuiKeyBackspace})
+	keys = append(keys, typeTUIKeys("2")...)
+	keys = append(keys, tuiKey{code: tuiKeyDown})
+	keys = append(keys, typeTUIKeys("--type mx --resolver 1.1.1.1")...)
+	keys = append(keys, tuiKey{code: tuiKeyEnter})
+
+	for _, k := range keys {
+		assert.False(t, tui.handleKey(t.Context(), k))
+	}
+
+	assert.Equal(t, "Creating the measurement...", tui.status)
+	tui.applyCreateUpdate(t.Context(), <-tui.creates)
+	tui.applyUpdate(<-tui.updates)
+	assert.Equal(t, "", tui.status)
+	assert.Equal(t, expectedMeasurement, tui.measurement)
+
+	b, err := _storage.GetMeasurements()
+	assert.NoError(t, err)
+	assert.Equal(t, measurementID1+"\n", string(b))
+
+	items, err := _storage.GetHistory(0)
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"1 | 2024-01-01 00:00:00 | dns jsdelivr.com from world --limit 2 --type mx --resolver 1.1.1.1\n> https://globalping.io?measurement=" + measurementID1}, items)
+
+	assert.Equal(t, `Globalping CLI v`+version.Version+`
+Measurement                | Results: table view | `+measurementID1+` | finished
+  Type:    < dns >         | Location     | Status | Answers | Time | Resolver
+  Target:  jsdelivr.com    | , , ,  (AS0) |      0 |       - |    - |        -
+  From:    world           |
+  Limit:   2               |
+> Options: --type mx --res |
+                           |
+History                    |
+> 1   dns jsdelivr.com fro |
+                           |
+                           |
+                           |
+                           |
+
+Tab: next pane | Up/Down: field | Left/Right: type | Enter: run | Ctrl+C: quit`, renderTUITest(tui, 80, 16))
+}
+
+func Test_TUI_History_Rerun(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	measurement := createDefaultMeasurement("ping")
+	measurement.Target = "jsdelivr.com"
+	measurement.Options = &globalping.MeasurementOptions{Packets: 5}
+
+	expectedOpts := &globalping.MeasurementCreate{
+		Type:              "ping",
+		Target:            "jsdelivr.com",
+		Limit:             1,
+		Locations:         globalping.PreviousMeasurementID(measurementID1),
+		InProgressUpdates: true,
+		Options:           &globalping.MeasurementOptions{Packets: 5},
+	}
+
+	rerunMeasurement := createDefaultMeasurement("ping")
+	rerunMeasurement.ID = measurementID2
+
+	gbMock := apiMocks.NewMockClient(ctrl)
+	gbMock.EXPECT().GetMeasurement(gomock.Any(), measurementID1).Return(measurement, nil)
+	gbMock.EXPECT().CreateMeasurement(gomock.Any(), expectedOpts).Return(&globalping.MeasurementCreateResponse{ID: measurementID2, ProbesCount: 1}, nil)
+	gbMock.EXPECT().GetMeasurement(gomock.Any(), measurementID2).Return(rerunMeasurement, nil)
+
+	utilsMock := utilsMocks.NewMockUtils(ctrl)
+	utilsMock.EXPECT().Now().Return(defaultCurrentTime).AnyTimes()
+	utilsMock.EXPECT().OpenBrowser(utils.ShareURL + measurementID2).Return(nil)
+
+	_storage := createDefaultTestStorage(t, utilsMock)
+	assert.NoError(t, _storage.SaveIdToSession(measurementID1))
+	assert.NoError(t, _storage.SaveCommandToHistory("1", defaultCurrentTime.Unix(), measurementID1, "ping jsdelivr.com --packets 5"))
+
+	printer := view.NewPrinter(nil, new(bytes.Buffer), new(bytes.Buffer))
+	root := NewRoot(printer, createDefaultContext(), nil, utilsMock, gbMock, nil, _storage)
+
+	tui := newTUI(root)
+	defer tui.stop()
+
+	tui.loadHistory()
+	tui.handleKey(t.Context(), tuiKey{code: tuiKeyBackTab})
+	assert.Equal(t, tuiPaneHistory, tui.focus)
+
+	// The measurement is fetched in the background, so the UI isn't blocked meanwhile
+	tui.handleKey(t.Context(), tuiKey{code: tuiKeyEnter})
+	assert.Equal(t, "", tui.id)
+	assert.Equal(t, "Loading the measurement...", tui.status)
+
+	tui.applyUpdate(<-tui.updates)
+	assert.Equal(t, measurementID1, tui.id)
+	assert.Equal(t, measurement, tui.measurement)
+	assert.Equal(t, "jsdelivr.com", tui.values[tuiFieldTarget])
+	assert.Equal(t, "", tui.status)
+
+	tui.handleKey(t.Context(), tuiKey{code: tuiKeyRune, r: 'r'})
+	tui.applyCreateUpdate(t.Context(), <-tui.creates)
+	assert.Equal(t, measurementID2, tui.id)
+	tui.applyUpdate(<-tui.updates)
+	assert.Equal(t, rerunMeasurement, tui.measurement)
+
+	items, err := _storage.GetHistory(0)
+	assert.NoError(t, err)
+	assert.Equal(t, "- | 2024-01-01 00:00:00 | ping jsdelivr.com from "+measurementID1+"\n> https://globalping.io?measurement="+measurementID2, items[1])
+	assert.Len(t, tui.history, 2)
+
+	tui.handleKey(t.Context(), tuiKey{code: tuiKeyRune, r: 'v'})
+	assert.Equal(t, tuiViewRaw, tui.view)
+	tui.handleKey(t.Context(), tuiKey{code: tuiKeyRune, r: 'v'})
+	assert.Equal(t, tuiViewLatency, tui.view)
+	tui.handleKey(t.Context(), tuiKey{code: tuiKeyRune, r: 'v'})
+	assert.Equal(t, tuiViewTable, tui.view)
+
+	tui.handleKey(t.Context(), tuiKey{code: tuiKeyRune, r: 'o'})
+	assert.Equal(t, "Opened the link in the browser.", tui.status)
+
+	assert.True(t, tui.handleKey(t.Context(), tuiKey{code: tuiKeyRune, r: 'q'}))
+}
+
+func Test_TUI_Create_WaitForCredits(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	gbMock := apiMocks.NewMockClient(ctrl)
+	gbMock.EXPECT().CreateMeasurement(gomock.Any(), gomock.Any()).Return(nil, &globalping.MeasurementError{
+		StatusCode: http.StatusTooManyRequests,
+		Type:       "too_many_requests",
+		Message:    "too many requests",
+		Header:     http.Header{"X-Ratelimit-Reset": []string{"3600"}},
+	})
+
+	utilsMock := utilsMocks.NewMockUtils(ctrl)
+	utilsMock.EXPECT().Now().Return(defaultCurrentTime).AnyTimes()
+
+	_storage := createDefaultTestStorage(t, utilsMock)
+	assert.NoError(t, _storage.SaveCachedProbes([]byte(`[]`)))
+
+	errW := new(bytes.Buffer)
+	printer := view.NewPrinter(nil, new(bytes.Buffer), errW)
+	ctx := createDefaultContext()
+	ctx.WaitForCredits = true
+	root := NewRoot(printer, ctx, nil, utilsMock, gbMock, nil, _storage)
+
+	tui := newTUI(root)
+	defer tui.stop()
+
+	tui.values[tuiFieldTarget] = "jsdelivr.com"
+	assert.False(t, tui.handleKey(t.Context(), tuiKey{code: tuiKeyEnter}))
+
+	// The wait is shown in the status line instead of being printed over the screen
+	tui.applyCreateUpdate(t.Context(), <-tui.creates)
+	assert.Equal(t, "Rate limit reached. Waiting 1 hour for the credits to reset (attempt 1 of 10)...", tui.status)
+	assert.Equal(t, "", errW.String())
+
+	// The UI keeps handling keys while waiting
+	assert.False(t, tui.handleKey(t.Context(), tuiKey{code: tuiKeyEnter}))
+	assert.Equal(t, "a measurement is already being created", tui.status)
+	assert.True(t, tui.handleKey(t.Context(), tuiKey{code: tuiKeyQuit}))
+
+	tui.stop()
+	assert.Nil(t, tui.stopCreate)
+}
+
+func Test_TUI_History_LoadError(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	gbMock := apiMocks.NewMockClient(ctrl)
+	gbMock.EXPECT().GetMeasurement(gomock.Any(), measurementID1).Return(nil, &globalping.MeasurementError{StatusCode: 404, Type: "not_found", Message: "Couldn't find the requested measurement."})
+
+	utilsMock := utilsMocks.NewMockUtils(ctrl)
+	utilsMock.EXPECT().Now().Return(defaultCurrentTime).AnyTimes()
+
+	_storage := createDefaultTestStorage(t, utilsMock)
+	assert.NoError(t, _storage.SaveIdToSession(measurementID1))
+	assert.NoError(t, _storage.SaveCommandToHistory("1", defaultCurrentTime.Unix(), measurementID1, "ping jsdelivr.com"))
+
+	printer := view.NewPrinter(nil, new(bytes.Buffer), new(bytes.Buffer))
+	root := NewRoot(printer, createDefaultContext(), nil, utilsMock, gbMock, nil, _storage)
+
+	tui := newTUI(root)
+	defer tui.stop()
+
+	tui.loadHistory()
+	tui.focus = tuiPaneHistory
+	tui.handleKey(t.Context(), tuiKey{code: tuiKeyEnter})
+	tui.applyUpdate(<-tui.updates)
+
+	assert.Equal(t, "not_found: Couldn't find the requested measurement.", tui.status)
+	assert.True(t, tui.isError)
+	assert.Equal(t, "", tui.id)
+	assert.Equal(t, "", tui.loading)
+}
+
+func Test_TUI_Errors(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	utilsMock := utilsMocks.NewMockUtils(ctrl)
+	utilsMock.EXPECT().Now().Return(defaultCurrentTime).AnyTimes()
+
+	printer := view.NewPrinter(nil, new(bytes.Buffer), new(bytes.Buffer))
+	root := NewRoot(printer, createDefaultContext(), nil, utilsMock, nil, nil, createDefaultTestStorage(t, utilsMock))
+
+	tui := newTUI(root)
+	tui.handleKey(t.Context(), tuiKey{code: tuiKeyEnter})
+	assert.Equal(t, "provided target is empty", tui.status)
+	assert.True(t, tui.isError)
+
+	tui.values[tuiFieldTarget] = "jsdelivr.com"
+	tui.values[tuiFieldOptions] = "--unknown"
+	tui.handleKey(t.Context(), tuiKey{code: tuiKeyEnter})
+	assert.Equal(t, "invalid options: unknown flag: --unknown", tui.status)
+
+	tui.handleKey(t.Context(), tuiKey{code: tuiKeyTab})
+	tui.handleKey(t.Context(), tuiKey{code: tuiKeyRune, r: 'r'})
+	assert.Equal(t, "no measurement selected", tui.status)
+
+	assert.True(t, tui.handleKey(t.Context(), tuiKey{code: tuiKeyQuit}))
+}
+
+func Test_ParseTUIKeys(t *testing.T) {
+	assert.Equal(t, []tuiKey{
+		{code: tuiKeyRune, r: 'a'},
+		{code: tuiKeyUp},
+		{code: tuiKeyBackTab},
+		{code: tuiKeyRune, r: 'é'},
+		{code: tuiKeyPageDown},
+		{code: tuiKeyRune, r: 'b'},
+		{code: tuiKeyLeft},
+		{code: tuiKeyEnter},
+		{code: tuiKeyTab},
+		{code: tuiKeyBackspace},
+		{code: tuiKeyQuit},
+	}, parseTUIKeys([]byte("a\033[A\033[Zé\033[6~\033[1;5Cb\033OD\r\t\x7f\x03\x01")))
+}
+
+func typeTUIKeys(s string) []tuiKey {
+	keys := make([]tuiKey, 0, len(s))
+
+	for _, r := range s {
+		keys = append(keys, tuiKey{code: tuiKeyRune, r: r})
+	}
+
+	return keys
+}
+
+// Returns the rendered screen without the escape sequences and trailing spaces.
+func renderTUITest(tui *tui, width, height int) string {
+	lines := strings.Split(strings.ReplaceAll(tui.render(width, height), "\033[K", ""), "\r\n")
+
+	for i := range lines {
+		lines[i] = strings.TrimRight(lines[i], " ")
+	}
+
+	return strings.Join(lines, "\n")
+}
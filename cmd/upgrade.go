@@ -0,0 +1,66 @@
+package cmd
+
+import (
+	"errors"
+	"fmt"
+	"regexp"
+
+	"github.com/jsdelivr/globalping-cli/version"
+	"github.com/spf13/cobra"
+)
+
+var ErrDevelopmentBuild = errors.New("development builds can't be upgraded")
+
+var releaseVersionRe = regexp.MustCompile(`^v?\d+(\.\d+)*$`)
+
+func (r *Root) initUpgrade() {
+	upgradeCmd := &cobra.Command{
+		RunE:  r.RunUpgrade,
+		Use:   "upgrade",
+		Short: "Upgrade the Globalping CLI to the latest version",
+		Long: `Upgrade the Globalping CLI to the latest version.
+Standalone binaries are replaced with the latest release after verifying its checksum. If the CLI was installed with a package manager, such as Homebrew, Snap, apt, or dnf, the command to upgrade it is printed instead.
+The releases are read from GitHub; set GLOBALPING_RELEASES_URL to use a different release feed.`,
+		Args: cobra.NoArgs,
+	}
+
+	r.Cmd.AddCommand(upgradeCmd)
+}
+
+func (r *Root) RunUpgrade(cmd *cobra.Command, _ []string) error {
+	cmd.SilenceUsage = true
+
+	// The version of a local build can't be compared with the releases, and replacing it is likely unintended
+	if !releaseVersionRe.MatchString(version.Version) {
+		return fmt.Errorf("%w: the version is %q, install a release to use the upgrade command", ErrDevelopmentBuild, version.Version)
+	}
+
+	release, err := r.updater.LatestRelease(cmd.Context())
+
+	if err != nil {
+		return err
+	}
+
+	if compareVersions(version.Version, release.Version()) >= 0 {
+		r.printer.Printf("Globalping CLI v%s is already the latest version.\n", version.Version)
+
+		return nil
+	}
+
+	if command := r.updater.UpgradeCommand(); command != "" {
+		r.printer.Printf("Globalping CLI was installed with %s. Run \"%s\" to upgrade to v%s.\n", r.updater.InstallMethod(), command, release.Version())
+
+		return nil
+	}
+
+	r.printer.Printf("Downloading Globalping CLI v%s...\n", release.Version())
+	err = r.updater.Upgrade(cmd.Context(), release)
+
+	if err != nil {
+		return err
+	}
+
+	r.printer.Printf("Upgraded Globalping CLI from v%s to v%s.\n", version.Version, release.Version())
+
+	return nil
+}
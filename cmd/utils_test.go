@@ -112,7 +112,19 @@ func createDefaultContext() *view.Context {
 	return ctx
 }
 
+// Returns a storage with an empty probe list in the cache, so that measurements don't fetch the probes for the location check.
 func createDefaultTestStorage(t *testing.T, utils utils.Utils) *storage.LocalStorage {
+	s := createUncachedTestStorage(t, utils)
+	err := s.SaveCachedProbes([]byte(`[]`))
+
+	if err != nil {
+		panic(err)
+	}
+
+	return s
+}
+
+func createUncachedTestStorage(t *testing.T, utils utils.Utils) *storage.LocalStorage {
 	s := storage.NewLocalStorage(utils)
 	err := s.Init("globalping-cli_" + t.Name())
 
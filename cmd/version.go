@@ -6,13 +6,49 @@ import (
 )
 
 func (r *Root) initVersion() {
-	r.Cmd.AddCommand(&cobra.Command{
-		Run:   r.RunVersion,
+	versionCmd := &cobra.Command{
+		RunE:  r.RunVersion,
 		Use:   "version",
 		Short: "Display the version of your installed Globalping CLI",
-	})
+		Args:  cobra.NoArgs,
+	}
+
+	versionCmd.Flags().Bool("check", false, "check whether a newer version is available (default false)")
+
+	r.Cmd.AddCommand(versionCmd)
 }
 
-func (r *Root) RunVersion(_ *cobra.Command, _ []string) {
+func (r *Root) RunVersion(cmd *cobra.Command, _ []string) error {
 	r.printer.Println("Globalping CLI v" + version.Version)
+
+	if check, _ := cmd.Flags().GetBool("check"); !check {
+		return nil
+	}
+
+	cmd.SilenceUsage = true
+	release, err := r.updater.LatestRelease(cmd.Context())
+
+	if err != nil {
+		return err
+	}
+
+	if compareVersions(version.Version, release.Version()) >= 0 {
+		r.printer.Println("You are using the latest version.")
+
+		return nil
+	}
+
+	r.printer.Printf("A new version is available: v%s (%s)\n", release.Version(), release.URL)
+	r.printer.Printf("Run \"%s\" to upgrade.\n", r.upgradeCommand())
+
+	return nil
+}
+
+// Returns the command which upgrades the CLI, depending on how it was installed.
+func (r *Root) upgradeCommand() string {
+	if command := r.updater.UpgradeCommand(); command != "" {
+		return command
+	}
+
+	return "globalping upgrade"
 }
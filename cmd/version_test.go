@@ -2,7 +2,10 @@ package cmd
 
 import (
 	"bytes"
+	"net/http"
+	"net/http/httptest"
 	"os"
+	"path/filepath"
 	"testing"
 
 	"github.com/jsdelivr/globalping-cli/version"
@@ -22,3 +25,82 @@ func Test_Execute_Version_Default(t *testing.T) {
 
 	assert.Equal(t, "Globalping CLI v1.0.0\n", w.String())
 }
+
+func Test_Execute_Version_Check(t *testing.T) {
+	version.Version = "1.4.0"
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		_, _ = w.Write([]byte(`{"tag_name":"v1.5.0","html_url":"https://github.com/jsdelivr/globalping-cli/releases/tag/v1.5.0"}`))
+	}))
+	defer server.Close()
+
+	w := new(bytes.Buffer)
+	printer := view.NewPrinter(nil, w, w)
+	root := NewRoot(printer, &view.Context{}, nil, nil, nil, nil, nil)
+	root.updater = version.NewUpdater(server.Client(), server.URL, filepath.Join(t.TempDir(), "globalping"))
+
+	os.Args = []string{"globalping", "version", "--check"}
+	err := root.Cmd.ExecuteContext(t.Context())
+	assert.NoError(t, err)
+
+	assert.Equal(t, `Globalping CLI v1.4.0
+A new version is available: v1.5.0 (https://github.com/jsdelivr/globalping-cli/releases/tag/v1.5.0)
+Run "globalping upgrade" to upgrade.
+`, w.String())
+}
+
+func Test_Execute_Upgrade_Packaged(t *testing.T) {
+	version.Version = "1.4.0"
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		_, _ = w.Write([]byte(`{"tag_name":"v1.5.0"}`))
+	}))
+	defer server.Close()
+
+	w := new(bytes.Buffer)
+	printer := view.NewPrinter(nil, w, w)
+	root := NewRoot(printer, &view.Context{}, nil, nil, nil, nil, nil)
+	root.updater = version.NewUpdater(server.Client(), server.URL, "/usr/local/Cellar/globalping/1.4.0/bin/globalping")
+
+	os.Args = []string{"globalping", "upgrade"}
+	err := root.Cmd.ExecuteContext(t.Context())
+	assert.NoError(t, err)
+
+	assert.Equal(t, "Globalping CLI was installed with Homebrew. Run \"brew upgrade globalping\" to upgrade to v1.5.0.\n", w.String())
+}
+
+func Test_Execute_Upgrade_DevelopmentBuild(t *testing.T) {
+	version.Version = "dev"
+	server := httptest.NewServer(http.HandlerFunc(func(_ http.ResponseWriter, _ *http.Request) {
+		t.Error("the releases should not be requested")
+	}))
+	defer server.Close()
+
+	w := new(bytes.Buffer)
+	printer := view.NewPrinter(nil, w, w)
+	root := NewRoot(printer, &view.Context{}, nil, nil, nil, nil, nil)
+	root.updater = version.NewUpdater(server.Client(), server.URL, filepath.Join(t.TempDir(), "globalping"))
+
+	os.Args = []string{"globalping", "upgrade"}
+	err := root.Cmd.ExecuteContext(t.Context())
+	assert.ErrorIs(t, err, ErrDevelopmentBuild)
+
+	assert.Equal(t, "Error: development builds can't be upgraded: the version is \"dev\", install a release to use the upgrade command\n", w.String())
+}
+
+func Test_Execute_Upgrade_Latest(t *testing.T) {
+	version.Version = "1.5.0"
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		_, _ = w.Write([]byte(`{"tag_name":"v1.5.0"}`))
+	}))
+	defer server.Close()
+
+	w := new(bytes.Buffer)
+	printer := view.NewPrinter(nil, w, w)
+	root := NewRoot(printer, &view.Context{}, nil, nil, nil, nil, nil)
+	root.updater = version.NewUpdater(server.Client(), server.URL, filepath.Join(t.TempDir(), "globalping"))
+
+	os.Args = []string{"globalping", "upgrade"}
+	err := root.Cmd.ExecuteContext(t.Context())
+	assert.NoError(t, err)
+
+	assert.Equal(t, "Globalping CLI v1.5.0 is already the latest version.\n", w.String())
+}
@@ -56,6 +56,35 @@ func (mr *MockClientMockRecorder) Authorize(ctx, callback any) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Authorize", reflect.TypeOf((*MockClient)(nil).Authorize), ctx, callback)
 }
 
+// AuthorizeDevice mocks base method.
+func (m *MockClient) AuthorizeDevice(ctx context.Context) (*api.DeviceAuthorizationResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AuthorizeDevice", ctx)
+	ret0, _ := ret[0].(*api.DeviceAuthorizationResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// AuthorizeDevice indicates an expected call of AuthorizeDevice.
+func (mr *MockClientMockRecorder) AuthorizeDevice(ctx any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AuthorizeDevice", reflect.TypeOf((*MockClient)(nil).AuthorizeDevice), ctx)
+}
+
+// AwaitDeviceToken mocks base method.
+func (m *MockClient) AwaitDeviceToken(ctx context.Context, device *api.DeviceAuthorizationResponse) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AwaitDeviceToken", ctx, device)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// AwaitDeviceToken indicates an expected call of AwaitDeviceToken.
+func (mr *MockClientMockRecorder) AwaitDeviceToken(ctx, device any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AwaitDeviceToken", reflect.TypeOf((*MockClient)(nil).AwaitDeviceToken), ctx, device)
+}
+
 // AwaitMeasurement mocks base method.
 func (m *MockClient) AwaitMeasurement(ctx context.Context, id string) (*globalping.Measurement, error) {
 	m.ctrl.T.Helper()
@@ -157,6 +186,21 @@ func (mr *MockClientMockRecorder) Logout(ctx any) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Logout", reflect.TypeOf((*MockClient)(nil).Logout), ctx)
 }
 
+// Probes mocks base method.
+func (m *MockClient) Probes(ctx context.Context) (*globalping.ProbesResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Probes", ctx)
+	ret0, _ := ret[0].(*globalping.ProbesResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Probes indicates an expected call of Probes.
+func (mr *MockClientMockRecorder) Probes(ctx any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Probes", reflect.TypeOf((*MockClient)(nil).Probes), ctx)
+}
+
 // RevokeToken mocks base method.
 func (m *MockClient) RevokeToken(ctx context.Context, token string) error {
 	m.ctrl.T.Helper()
@@ -10,6 +10,7 @@
 package api
 
 import (
+	io "io"
 	reflect "reflect"
 
 	probe "github.com/jsdelivr/globalping-cli/api/probe"
@@ -39,6 +40,35 @@ func (m *MockProbe) EXPECT() *MockProbeMockRecorder {
 	return m.recorder
 }
 
+// ContainerLogs mocks base method.
+func (m *MockProbe) ContainerLogs(c *probe.Container, tail int, follow bool, w io.Writer) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ContainerLogs", c, tail, follow, w)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ContainerLogs indicates an expected call of ContainerLogs.
+func (mr *MockProbeMockRecorder) ContainerLogs(c, tail, follow, w any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ContainerLogs", reflect.TypeOf((*MockProbe)(nil).ContainerLogs), c, tail, follow, w)
+}
+
+// ContainerStatus mocks base method.
+func (m *MockProbe) ContainerStatus(c *probe.Container) (*probe.ContainerStatus, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ContainerStatus", c)
+	ret0, _ := ret[0].(*probe.ContainerStatus)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ContainerStatus indicates an expected call of ContainerStatus.
+func (mr *MockProbeMockRecorder) ContainerStatus(c any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ContainerStatus", reflect.TypeOf((*MockProbe)(nil).ContainerStatus), c)
+}
+
 // DetectContainerEngine mocks base method.
 func (m *MockProbe) DetectContainerEngine() (probe.ContainerEngine, error) {
 	m.ctrl.T.Helper()
@@ -55,29 +85,101 @@ func (mr *MockProbeMockRecorder) DetectContainerEngine() *gomock.Call {
 }
 
 // InspectContainer mocks base method.
-func (m *MockProbe) InspectContainer(containerEngine probe.ContainerEngine) error {
+func (m *MockProbe) InspectContainer(c *probe.Container) error {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "InspectContainer", containerEngine)
+	ret := m.ctrl.Call(m, "InspectContainer", c)
 	ret0, _ := ret[0].(error)
 	return ret0
 }
 
 // InspectContainer indicates an expected call of InspectContainer.
-func (mr *MockProbeMockRecorder) InspectContainer(containerEngine any) *gomock.Call {
+func (mr *MockProbeMockRecorder) InspectContainer(c any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "InspectContainer", reflect.TypeOf((*MockProbe)(nil).InspectContainer), c)
+}
+
+// PullImage mocks base method.
+func (m *MockProbe) PullImage(c *probe.Container) (string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "PullImage", c)
+	ret0, _ := ret[0].(string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// PullImage indicates an expected call of PullImage.
+func (mr *MockProbeMockRecorder) PullImage(c any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PullImage", reflect.TypeOf((*MockProbe)(nil).PullImage), c)
+}
+
+// RemoveContainer mocks base method.
+func (m *MockProbe) RemoveContainer(c *probe.Container) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RemoveContainer", c)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RemoveContainer indicates an expected call of RemoveContainer.
+func (mr *MockProbeMockRecorder) RemoveContainer(c any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RemoveContainer", reflect.TypeOf((*MockProbe)(nil).RemoveContainer), c)
+}
+
+// ReplaceContainer mocks base method.
+func (m *MockProbe) ReplaceContainer(c *probe.Container) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ReplaceContainer", c)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ReplaceContainer indicates an expected call of ReplaceContainer.
+func (mr *MockProbeMockRecorder) ReplaceContainer(c any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ReplaceContainer", reflect.TypeOf((*MockProbe)(nil).ReplaceContainer), c)
+}
+
+// RestartContainer mocks base method.
+func (m *MockProbe) RestartContainer(c *probe.Container) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RestartContainer", c)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RestartContainer indicates an expected call of RestartContainer.
+func (mr *MockProbeMockRecorder) RestartContainer(c any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "InspectContainer", reflect.TypeOf((*MockProbe)(nil).InspectContainer), containerEngine)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RestartContainer", reflect.TypeOf((*MockProbe)(nil).RestartContainer), c)
 }
 
 // RunContainer mocks base method.
-func (m *MockProbe) RunContainer(containerEngine probe.ContainerEngine) error {
+func (m *MockProbe) RunContainer(c *probe.Container) error {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "RunContainer", containerEngine)
+	ret := m.ctrl.Call(m, "RunContainer", c)
 	ret0, _ := ret[0].(error)
 	return ret0
 }
 
 // RunContainer indicates an expected call of RunContainer.
-func (mr *MockProbeMockRecorder) RunContainer(containerEngine any) *gomock.Call {
+func (mr *MockProbeMockRecorder) RunContainer(c any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RunContainer", reflect.TypeOf((*MockProbe)(nil).RunContainer), c)
+}
+
+// WriteArtifact mocks base method.
+func (m *MockProbe) WriteArtifact(format probe.ArtifactFormat, c *probe.Container, content string, overwrite bool) (string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "WriteArtifact", format, c, content, overwrite)
+	ret0, _ := ret[0].(string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// WriteArtifact indicates an expected call of WriteArtifact.
+func (mr *MockProbeMockRecorder) WriteArtifact(format, c, content, overwrite any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RunContainer", reflect.TypeOf((*MockProbe)(nil).RunContainer), containerEngine)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "WriteArtifact", reflect.TypeOf((*MockProbe)(nil).WriteArtifact), format, c, content, overwrite)
 }
@@ -10,6 +10,7 @@
 package utils
 
 import (
+	io "io"
 	reflect "reflect"
 	time "time"
 
@@ -66,3 +67,17 @@ func (mr *MockUtilsMockRecorder) OpenBrowser(url any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "OpenBrowser", reflect.TypeOf((*MockUtils)(nil).OpenBrowser), url)
 }
+
+// RunCommand mocks base method.
+func (m *MockUtils) RunCommand(command string, env []string, out io.Writer) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RunCommand", command, env, out)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RunCommand indicates an expected call of RunCommand.
+func (mr *MockUtilsMockRecorder) RunCommand(command, env, out any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RunCommand", reflect.TypeOf((*MockUtils)(nil).RunCommand), command, env, out)
+}
@@ -0,0 +1,166 @@
+package storage
+
+import (
+	"encoding/json"
+	"errors"
+	"io/fs"
+	"os"
+	"slices"
+	"strings"
+	"time"
+)
+
+var (
+	ErrBookmarkNotFound = errors.New("bookmark not found")
+)
+
+var (
+	bookmarksFileName = "bookmarks.json"
+)
+
+type Bookmark struct {
+	ID        string    `json:"id"` // Measurement ID
+	Tags      []string  `json:"tags,omitempty"`
+	Note      string    `json:"note,omitempty"`
+	Command   string    `json:"command,omitempty"`
+	Session   string    `json:"session,omitempty"` // The session is kept during cleanup
+	CreatedAt time.Time `json:"created_at"`
+}
+
+// Returns a short description of the bookmark, e.g. "#incident-4312 before failover".
+func (b *Bookmark) Label() string {
+	parts := make([]string, 0, len(b.Tags)+1)
+
+	for _, tag := range b.Tags {
+		parts = append(parts, "#"+tag)
+	}
+
+	if b.Note != "" {
+		parts = append(parts, b.Note)
+	}
+
+	return strings.Join(parts, " ")
+}
+
+// Returns all bookmarks, the oldest first.
+func (s *LocalStorage) GetBookmarks() ([]*Bookmark, error) {
+	bookmarks := make([]*Bookmark, 0)
+	b, err := os.ReadFile(s.bookmarksPath())
+
+	if err != nil {
+		if errors.Is(err, fs.ErrNotExist) {
+			return bookmarks, nil
+		}
+
+		return nil, err
+	}
+
+	err = json.Unmarshal(b, &bookmarks)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return bookmarks, nil
+}
+
+// Saves the bookmark. If the measurement is already bookmarked, the tags are merged and the note is replaced if set.
+func (s *LocalStorage) SaveBookmark(bookmark *Bookmark) error {
+	bookmarks, err := s.GetBookmarks()
+
+	if err != nil {
+		return err
+	}
+
+	i := slices.IndexFunc(bookmarks, func(b *Bookmark) bool { return b.ID == bookmark.ID })
+
+	if i == -1 {
+		bookmarks = append(bookmarks, bookmark)
+	} else {
+		existing := bookmarks[i]
+
+		for _, tag := range bookmark.Tags {
+			if !slices.Contains(existing.Tags, tag) {
+				existing.Tags = append(existing.Tags, tag)
+			}
+		}
+
+		if bookmark.Note != "" {
+			existing.Note = bookmark.Note
+		}
+
+		if existing.Command == "" {
+			existing.Command = bookmark.Command
+		}
+
+		if existing.Session == "" {
+			existing.Session = bookmark.Session
+		}
+	}
+
+	return s.saveBookmarks(bookmarks)
+}
+
+func (s *LocalStorage) DeleteBookmark(id string) error {
+	bookmarks, err := s.GetBookmarks()
+
+	if err != nil {
+		return err
+	}
+
+	i := slices.IndexFunc(bookmarks, func(b *Bookmark) bool { return b.ID == id })
+
+	if i == -1 {
+		return ErrBookmarkNotFound
+	}
+
+	return s.saveBookmarks(slices.Delete(bookmarks, i, i+1))
+}
+
+// Returns the most recent bookmark with the given tag.
+func (s *LocalStorage) GetBookmarkByTag(tag string) (*Bookmark, error) {
+	bookmarks, err := s.GetBookmarks()
+
+	if err != nil {
+		return nil, err
+	}
+
+	var found *Bookmark
+
+	for _, b := range bookmarks {
+		if slices.Contains(b.Tags, tag) && (found == nil || !b.CreatedAt.Before(found.CreatedAt)) {
+			found = b
+		}
+	}
+
+	if found == nil {
+		return nil, ErrBookmarkNotFound
+	}
+
+	return found, nil
+}
+
+func (s *LocalStorage) getBookmarksById() map[string]*Bookmark {
+	bookmarks, _ := s.GetBookmarks()
+	m := make(map[string]*Bookmark, len(bookmarks))
+
+	for _, b := range bookmarks {
+		m[b.ID] = b
+	}
+
+	return m
+}
+
+func (s *LocalStorage) saveBookmarks(bookmarks []*Bookmark) error {
+	b, err := json.Marshal(bookmarks)
+
+	if err != nil {
+		return err
+	}
+
+	return os.WriteFile(s.bookmarksPath(), b, 0644)
+}
+
+func (s *LocalStorage) bookmarksPath() string {
+	return s.joinConfigDir(bookmarksFileName)
+}
@@ -0,0 +1,87 @@
+package storage
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/jsdelivr/globalping-cli/utils"
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_Bookmarks(t *testing.T) {
+	_storage := createDefaultTestStorage(t)
+
+	bookmarks, err := _storage.GetBookmarks()
+	assert.NoError(t, err)
+	assert.Empty(t, bookmarks)
+
+	t1 := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	t2 := t1.Add(time.Hour)
+
+	assert.NoError(t, _storage.SaveBookmark(&Bookmark{ID: "id1", Tags: []string{"incident"}, Note: "before", CreatedAt: t1}))
+	assert.NoError(t, _storage.SaveBookmark(&Bookmark{ID: "id2", Tags: []string{"incident"}, CreatedAt: t2}))
+	assert.NoError(t, _storage.SaveBookmark(&Bookmark{ID: "id1", Tags: []string{"eu", "incident"}, Command: "ping google.com", CreatedAt: t2}))
+
+	bookmarks, err = _storage.GetBookmarks()
+	assert.NoError(t, err)
+	assert.Equal(t, []*Bookmark{
+		{ID: "id1", Tags: []string{"incident", "eu"}, Note: "before", Command: "ping google.com", CreatedAt: t1},
+		{ID: "id2", Tags: []string{"incident"}, CreatedAt: t2},
+	}, bookmarks)
+	assert.Equal(t, "#incident #eu before", bookmarks[0].Label())
+
+	b, err := _storage.GetBookmarkByTag("incident")
+	assert.NoError(t, err)
+	assert.Equal(t, "id2", b.ID)
+
+	b, err = _storage.GetBookmarkByTag("eu")
+	assert.NoError(t, err)
+	assert.Equal(t, "id1", b.ID)
+
+	_, err = _storage.GetBookmarkByTag("unknown")
+	assert.ErrorIs(t, err, ErrBookmarkNotFound)
+
+	assert.NoError(t, _storage.DeleteBookmark("id2"))
+	assert.ErrorIs(t, _storage.DeleteBookmark("id2"), ErrBookmarkNotFound)
+
+	b, err = _storage.GetBookmarkByTag("incident")
+	assert.NoError(t, err)
+	assert.Equal(t, "id1", b.ID)
+}
+
+func Test_Bookmarks_History(t *testing.T) {
+	_storage := createDefaultTestStorage(t)
+	assert.NoError(t, _storage.SaveCommandToHistory("1", 0, "id1.id2", "ping google.com --infinite"))
+	assert.NoError(t, _storage.SaveCommandToHistory("2", 0, "id3", "dns google.com"))
+	assert.NoError(t, _storage.SaveBookmark(&Bookmark{ID: "id2", Tags: []string{"incident"}, Note: "before failover"}))
+
+	assert.Equal(t, "ping google.com --infinite", _storage.GetHistoryCommand("id2"))
+	assert.Equal(t, "", _storage.GetHistoryCommand("id4"))
+
+	items, err := _storage.GetHistory(0)
+	assert.NoError(t, err)
+	assert.Equal(t, []string{
+		"1 | " + time.Unix(0, 0).Format("2006-01-02 15:04:05") + " | ping google.com --infinite | bookmarked: #incident before failover\n> https://globalping.io?measurement=id1.id2",
+		"2 | " + time.Unix(0, 0).Format("2006-01-02 15:04:05") + " | dns google.com\n> https://globalping.io?measurement=id3",
+	}, items)
+}
+
+func Test_Bookmarks_Cleanup(t *testing.T) {
+	_storage := createDefaultTestStorage(t)
+	_storage.utils = utils.NewUtils()
+	old := time.Now().AddDate(0, 0, -30)
+
+	for _, id := range []string{"1_1", "2_2"} {
+		dir := filepath.Join(_storage.sessionsDir, id)
+		assert.NoError(t, os.MkdirAll(dir, 0755))
+		assert.NoError(t, os.Chtimes(dir, old, old))
+	}
+
+	assert.NoError(t, _storage.SaveBookmark(&Bookmark{ID: "id1", Session: "1_1"}))
+	assert.NoError(t, _storage.Cleanup())
+
+	assert.DirExists(t, filepath.Join(_storage.sessionsDir, "1_1"))
+	assert.NoDirExists(t, filepath.Join(_storage.sessionsDir, "2_2"))
+}
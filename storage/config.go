@@ -2,10 +2,21 @@ package storage
 
 import (
 	"encoding/json"
+	"errors"
+	"fmt"
 	"os"
+	"slices"
+	"strings"
 	"time"
 )
 
+var (
+	ErrProfileNotFound    = errors.New("profile not found")
+	ErrInvalidProfileName = errors.New("invalid profile name")
+)
+
+const DefaultProfileName = "default"
+
 type Token struct {
 	AccessToken  string    `json:"access_token"`
 	TokenType    string    `json:"token_type,omitempty"`
@@ -15,7 +26,8 @@ type Token struct {
 }
 
 type Profile struct {
-	Token *Token `json:"token"`
+	Token          *Token `json:"token"`
+	EncryptedToken string `json:"encrypted_token,omitempty"` // Set only in the file, or if the token could not be decrypted
 }
 
 type Config struct {
@@ -24,7 +36,6 @@ type Config struct {
 	LastMigration int                 `json:"last_migration"`
 }
 
-// TODO: Implement locking mechanism
 func (s *LocalStorage) LoadConfig() (*Config, error) {
 	if s.config != nil {
 		return s.config, nil
@@ -38,7 +49,7 @@ func (s *LocalStorage) LoadConfig() (*Config, error) {
 	}
 
 	s.config = &Config{
-		Profile:  "default",
+		Profile:  DefaultProfileName,
 		Profiles: make(map[string]*Profile),
 	}
 	err = json.Unmarshal(b, s.config)
@@ -47,31 +58,195 @@ func (s *LocalStorage) LoadConfig() (*Config, error) {
 		return nil, err
 	}
 
+	s.tokenErrors = nil
+	s.plaintextTokens = false
+
+	for name, p := range s.config.Profiles {
+		if p == nil {
+			continue
+		}
+
+		if p.EncryptedToken == "" {
+			s.plaintextTokens = s.plaintextTokens || p.Token != nil
+
+			continue
+		}
+
+		token, err := s.decryptToken(p.EncryptedToken)
+
+		if err != nil {
+			// The encrypted token is kept, so that it isn't lost if the key is set incorrectly
+			s.tokenErrors = append(s.tokenErrors, fmt.Errorf("failed to decrypt the token of profile %s: %w", name, err))
+
+			continue
+		}
+
+		p.Token = token
+		p.EncryptedToken = ""
+	}
+
 	return s.config, nil
 }
 
+// Discards the cached config and loads it from the disk, to pick up changes made by other processes.
+func (s *LocalStorage) ReloadConfig() (*Config, error) {
+	config := s.config
+	s.config = nil
+	c, err := s.LoadConfig()
+
+	if err != nil {
+		s.config = config
+
+		return nil, err
+	}
+
+	return c, nil
+}
+
 func (s *LocalStorage) SaveConfig() error {
 	if s.config == nil {
 		return nil
 	}
 
+	// Tokens are written encrypted if a token key is set
+	config := *s.config
+	config.Profiles = make(map[string]*Profile, len(s.config.Profiles))
+	plaintextTokens := false
+
+	for name, p := range s.config.Profiles {
+		if p == nil || p.Token == nil {
+			config.Profiles[name] = p
+
+			continue
+		}
+
+		if !s.tokenEncryptionEnabled() {
+			config.Profiles[name] = p
+			plaintextTokens = true
+
+			continue
+		}
+
+		encrypted, err := s.encryptToken(p.Token)
+
+		if err != nil {
+			return fmt.Errorf("failed to encrypt the token of profile %s: %w", name, err)
+		}
+
+		config.Profiles[name] = &Profile{EncryptedToken: encrypted}
+	}
+
 	path := s.joinConfigDir(s.configName)
-	b, err := json.Marshal(s.config)
+	b, err := json.Marshal(config)
 
 	if err != nil {
 		return err
 	}
 
-	return os.WriteFile(path, b, 0644)
+	err = os.WriteFile(path, b, 0600)
+
+	if err != nil {
+		return err
+	}
+
+	s.plaintextTokens = plaintextTokens
+
+	// WriteFile doesn't change the permissions of existing files
+	return os.Chmod(path, 0600)
 }
 
+// Returns the active profile, creating it if it does not exist yet.
 func (s *LocalStorage) GetProfile() *Profile {
-	p := s.config.Profiles[s.config.Profile]
+	name := s.ProfileName()
+	p := s.config.Profiles[name]
 
 	if p == nil {
 		p = &Profile{}
-		s.config.Profiles[s.config.Profile] = p
+		s.config.Profiles[name] = p
 	}
 
 	return p
 }
+
+// Returns the profile with the given name or nil if it does not exist.
+func (s *LocalStorage) GetProfileByName(name string) *Profile {
+	return s.config.Profiles[name]
+}
+
+// Returns the name of the active profile.
+func (s *LocalStorage) ProfileName() string {
+	if s.profile != "" {
+		return s.profile
+	}
+
+	if s.config.Profile == "" {
+		return DefaultProfileName
+	}
+
+	return s.config.Profile
+}
+
+// Returns the names of all stored profiles, sorted alphabetically.
+func (s *LocalStorage) ProfileNames() []string {
+	names := make([]string, 0, len(s.config.Profiles))
+
+	for name := range s.config.Profiles {
+		names = append(names, name)
+	}
+
+	slices.Sort(names)
+
+	return names
+}
+
+// Selects the active profile for the current process only. The stored default profile is not changed.
+func (s *LocalStorage) SelectProfile(name string) error {
+	if !isValidName(name) {
+		return ErrInvalidProfileName
+	}
+
+	s.profile = name
+
+	return nil
+}
+
+// Sets the stored default profile.
+func (s *LocalStorage) UseProfile(name string) error {
+	if !isValidName(name) {
+		return ErrInvalidProfileName
+	}
+
+	if s.config.Profiles[name] == nil {
+		return ErrProfileNotFound
+	}
+
+	s.config.Profile = name
+	s.profile = ""
+
+	return s.SaveConfig()
+}
+
+// Removes the profile with the given name. If it was the stored default profile, the default is reset.
+func (s *LocalStorage) DeleteProfile(name string) error {
+	if s.config.Profiles[name] == nil {
+		return ErrProfileNotFound
+	}
+
+	delete(s.config.Profiles, name)
+
+	if s.config.Profile == name {
+		s.config.Profile = DefaultProfileName
+	}
+
+	if s.profile == name {
+		s.profile = ""
+	}
+
+	return s.SaveConfig()
+}
+
+func isValidName(name string) bool {
+	return name != "" && !strings.ContainsFunc(name, func(r rune) bool {
+		return r <= ' ' || r == '/' || r == '\\'
+	})
+}
@@ -20,7 +20,7 @@ func Test_Config(t *testing.T) {
 	assert.Equal(t, &Config{
 		Profile:       "default",
 		Profiles:      make(map[string]*Profile),
-		LastMigration: 2,
+		LastMigration: 4,
 	}, config)
 
 	profile := _storage.GetProfile()
@@ -50,11 +50,52 @@ func Test_Config(t *testing.T) {
 		t.Fatal(err)
 	}
 
+	// Without a token key, the tokens are only protected by the file permissions
 	assert.Equal(t, &Config{
 		Profile: "default",
 		Profiles: map[string]*Profile{
 			"default": {Token: profile.Token},
 		},
-		LastMigration: 2,
+		LastMigration: 4,
 	}, c)
+
+	info, err := os.Stat(path)
+	assert.NoError(t, err)
+	assert.Equal(t, os.FileMode(0600), info.Mode().Perm())
+}
+
+func Test_Profiles(t *testing.T) {
+	_storage := createDefaultTestStorage(t)
+	_, err := _storage.LoadConfig()
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	assert.Equal(t, DefaultProfileName, _storage.ProfileName())
+
+	_storage.GetProfile().Token = &Token{AccessToken: "default_token"}
+
+	assert.NoError(t, _storage.SelectProfile("work"))
+	assert.Equal(t, "work", _storage.ProfileName())
+	assert.Equal(t, &Profile{}, _storage.GetProfile())
+
+	_storage.GetProfile().Token = &Token{AccessToken: "work_token"}
+	assert.NoError(t, _storage.SaveConfig())
+	assert.Equal(t, []string{"default", "work"}, _storage.ProfileNames())
+	assert.Equal(t, "default", _storage.config.Profile)
+
+	assert.ErrorIs(t, _storage.SelectProfile(""), ErrInvalidProfileName)
+	assert.ErrorIs(t, _storage.SelectProfile("my work"), ErrInvalidProfileName)
+	assert.ErrorIs(t, _storage.UseProfile("personal"), ErrProfileNotFound)
+
+	assert.NoError(t, _storage.UseProfile("work"))
+	assert.Equal(t, "work", _storage.config.Profile)
+	assert.Equal(t, "work_token", _storage.GetProfile().Token.AccessToken)
+
+	assert.NoError(t, _storage.DeleteProfile("work"))
+	assert.Equal(t, DefaultProfileName, _storage.ProfileName())
+	assert.Equal(t, "default_token", _storage.GetProfile().Token.AccessToken)
+	assert.Nil(t, _storage.GetProfileByName("work"))
+	assert.ErrorIs(t, _storage.DeleteProfile("work"), ErrProfileNotFound)
 }
@@ -7,6 +7,8 @@ import (
 	"io"
 	"io/fs"
 	"os"
+	"path/filepath"
+	"slices"
 	"strconv"
 	"strings"
 	"time"
@@ -88,9 +90,47 @@ func (s *LocalStorage) GetHistoryIndex() (int, error) {
 }
 
 func (s *LocalStorage) GetHistory(limit int) ([]string, error) {
-	items := make([]string, 0)
+	return s.getHistory(s.historyPath(), limit)
+}
+
+// Returns the history of the session with the given ID.
+func (s *LocalStorage) GetSessionHistory(id string, limit int) ([]string, error) {
+	return s.getHistory(filepath.Join(s.sessionsDir, id, historyFileName), limit)
+}
+
+// Returns the command of the history item containing the measurement ID in the current session.
+func (s *LocalStorage) GetHistoryCommand(id string) string {
 	f, err := os.Open(s.historyPath())
 
+	if err != nil {
+		return ""
+	}
+
+	defer func() {
+		_ = f.Close()
+	}()
+	scanner := bufio.NewScanner(f)
+
+	for scanner.Scan() {
+		parts, err := getHistoryItem(scanner.Text())
+
+		if err != nil {
+			continue
+		}
+
+		if slices.Contains(strings.Split(parts[3], "."), id) {
+			return parts[4]
+		}
+	}
+
+	return ""
+}
+
+func (s *LocalStorage) getHistory(path string, limit int) ([]string, error) {
+	items := make([]string, 0)
+	bookmarks := s.getBookmarksById()
+	f, err := os.Open(path)
+
 	if err != nil {
 		if errors.Is(err, fs.ErrNotExist) {
 			return items, nil
@@ -128,7 +168,7 @@ func (s *LocalStorage) GetHistory(limit int) ([]string, error) {
 				return nil, ErrReadHistory
 			}
 
-			item, err := parseHistoryItem(string(b))
+			item, err := parseHistoryItem(string(b), bookmarks)
 
 			if err != nil {
 				return nil, err
@@ -148,7 +188,7 @@ func (s *LocalStorage) GetHistory(limit int) ([]string, error) {
 
 	for scanner.Scan() {
 		limit--
-		item, err := parseHistoryItem(scanner.Text())
+		item, err := parseHistoryItem(scanner.Text(), bookmarks)
 
 		if err != nil {
 			return nil, err
@@ -192,7 +232,7 @@ func (s *LocalStorage) historyPath() string {
 	return s.joinSessionDir(historyFileName)
 }
 
-func parseHistoryItem(line string) (string, error) {
+func parseHistoryItem(line string, bookmarks map[string]*Bookmark) (string, error) {
 	parts, err := getHistoryItem(line)
 
 	if err != nil {
@@ -206,11 +246,24 @@ func parseHistoryItem(line string) (string, error) {
 			return "", fmt.Errorf(invalidHistoryItemErr, line)
 		}
 
+		cmd := parts[4]
+		labels := make([]string, 0)
+
+		for id := range strings.SplitSeq(parts[3], ".") {
+			if b := bookmarks[id]; b != nil {
+				labels = append(labels, b.Label())
+			}
+		}
+
+		if len(labels) > 0 {
+			cmd += " | bookmarked: " + strings.Join(labels, "; ")
+		}
+
 		return fmt.Sprintf(
 			"%s | %s | %s\n%s",
 			parts[1],
 			time.Unix(t, 0).Format("2006-01-02 15:04:05"),
-			parts[4],
+			cmd,
 			"> "+utils.ShareURL+parts[3],
 		), nil
 	}
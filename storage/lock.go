@@ -0,0 +1,66 @@
+package storage
+
+import (
+	"context"
+	"errors"
+	"os"
+	"strconv"
+	"time"
+)
+
+var ErrConfigLocked = errors.New("timed out waiting for another globalping process to release the config")
+
+const configLockFileName = "config.lock"
+
+var (
+	configLockTimeout  = 10 * time.Second
+	configLockInterval = 50 * time.Millisecond
+
+	// Locks older than this are left over from a crashed process. The lock is held during the token refresh request,
+	// so this is well above the 30s timeout of the API client, to not take over the lock of a slow refresh.
+	configLockStale = 2 * time.Minute
+)
+
+// Acquires an exclusive lock on the config, shared by all processes using the same config directory.
+// The returned function releases the lock.
+func (s *LocalStorage) LockConfig(ctx context.Context) (func(), error) {
+	path := s.joinConfigDir(configLockFileName)
+	deadline := time.Now().Add(configLockTimeout)
+
+	for {
+		f, err := os.OpenFile(path, os.O_CREATE|os.O_EXCL|os.O_WRONLY, 0600)
+
+		if err == nil {
+			_, _ = f.WriteString(strconv.Itoa(os.Getpid()))
+			_ = f.Close()
+
+			return func() {
+				_ = os.Remove(path)
+			}, nil
+		}
+
+		if !os.IsExist(err) {
+			return nil, err
+		}
+
+		if info, err := os.Stat(path); err == nil && time.Since(info.ModTime()) > configLockStale {
+			_ = os.Remove(path)
+
+			continue
+		}
+
+		if time.Now().After(deadline) {
+			return nil, ErrConfigLocked
+		}
+
+		timer := time.NewTimer(configLockInterval)
+
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+
+			return nil, ctx.Err()
+		case <-timer.C:
+		}
+	}
+}
@@ -0,0 +1,57 @@
+package storage
+
+import (
+	"os"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_LockConfig(t *testing.T) {
+	_storage := createDefaultTestStorage(t)
+	configLockTimeout = 100 * time.Millisecond
+	defer func() {
+		configLockTimeout = 10 * time.Second
+	}()
+
+	unlock, err := _storage.LockConfig(t.Context())
+	assert.NoError(t, err)
+
+	_, err = _storage.LockConfig(t.Context())
+	assert.ErrorIs(t, err, ErrConfigLocked)
+
+	unlock()
+
+	unlock, err = _storage.LockConfig(t.Context())
+	assert.NoError(t, err)
+	unlock()
+}
+
+func Test_LockConfig_Stale(t *testing.T) {
+	_storage := createDefaultTestStorage(t)
+	configLockTimeout = 100 * time.Millisecond
+	defer func() {
+		configLockTimeout = 10 * time.Second
+	}()
+
+	path := _storage.joinConfigDir(configLockFileName)
+	assert.NoError(t, os.WriteFile(path, []byte("1"), 0600))
+
+	// A lock held during a slow token refresh is not stale
+	refreshTime := time.Now().Add(-time.Minute)
+	assert.NoError(t, os.Chtimes(path, refreshTime, refreshTime))
+
+	_, err := _storage.LockConfig(t.Context())
+	assert.ErrorIs(t, err, ErrConfigLocked)
+
+	staleTime := time.Now().Add(-3 * time.Minute)
+	assert.NoError(t, os.Chtimes(path, staleTime, staleTime))
+
+	unlock, err := _storage.LockConfig(t.Context())
+	assert.NoError(t, err)
+	unlock()
+
+	_, err = os.Stat(path)
+	assert.True(t, os.IsNotExist(err))
+}
@@ -117,3 +117,14 @@ func (s *LocalStorage) MoveSessionsToUserDir() error {
 
 	return os.RemoveAll(oldSessionsDir)
 }
+
+// Restricts the permissions of the config directory and file.
+func (s *LocalStorage) RestrictConfigPermissions() error {
+	err := os.Chmod(s.configDir, 0700)
+
+	if err != nil {
+		return err
+	}
+
+	return os.Chmod(s.joinConfigDir(s.configName), 0600)
+}
@@ -0,0 +1,34 @@
+package storage
+
+import (
+	"os"
+	"path/filepath"
+	"time"
+)
+
+const probesCacheFileName = "probes.json"
+
+// Returns the cached probe list, or nil if it doesn't exist or is older than maxAge.
+func (s *LocalStorage) GetCachedProbes(maxAge time.Duration) ([]byte, error) {
+	path := filepath.Join(s.tempDir, probesCacheFileName)
+	info, err := os.Stat(path)
+
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, nil
+		}
+
+		return nil, err
+	}
+
+	if time.Since(info.ModTime()) > maxAge {
+		return nil, nil
+	}
+
+	return os.ReadFile(path)
+}
+
+// Saves the probe list, which is shared by all sessions of the current user.
+func (s *LocalStorage) SaveCachedProbes(b []byte) error {
+	return os.WriteFile(filepath.Join(s.tempDir, probesCacheFileName), b, 0644)
+}
@@ -0,0 +1,31 @@
+package storage
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_CachedProbes(t *testing.T) {
+	_storage := createDefaultTestStorage(t)
+
+	b, err := _storage.GetCachedProbes(time.Hour)
+	assert.NoError(t, err)
+	assert.Nil(t, b)
+
+	assert.NoError(t, _storage.SaveCachedProbes([]byte(`[]`)))
+
+	b, err = _storage.GetCachedProbes(time.Hour)
+	assert.NoError(t, err)
+	assert.Equal(t, []byte(`[]`), b)
+
+	modTime := time.Now().Add(-2 * time.Hour)
+	assert.NoError(t, os.Chtimes(filepath.Join(_storage.tempDir, probesCacheFileName), modTime, modTime))
+
+	b, err = _storage.GetCachedProbes(time.Hour)
+	assert.NoError(t, err)
+	assert.Nil(t, b)
+}
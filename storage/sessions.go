@@ -0,0 +1,232 @@
+package storage
+
+import (
+	"bytes"
+	"errors"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"time"
+)
+
+var (
+	ErrSessionNotFound    = errors.New("session not found")
+	ErrSessionNameTaken   = errors.New("session name is already in use")
+	ErrInvalidSessionName = errors.New("invalid session name")
+)
+
+var (
+	sessionNameFileName = "name"
+	sessionLinkFileName = "link" // Stored in the terminal session and contains the ID of the session used instead
+)
+
+type Session struct {
+	ID           string
+	Name         string
+	Measurements int
+	UpdatedAt    time.Time
+	IsCurrent    bool
+}
+
+// Returns all sessions, the most recently used first.
+func (s *LocalStorage) GetSessions() ([]*Session, error) {
+	entries, err := os.ReadDir(s.sessionsDir)
+
+	if err != nil {
+		return nil, err
+	}
+
+	current := s.CurrentSessionID()
+	sessions := make([]*Session, 0, len(entries))
+
+	for _, e := range entries {
+		if !e.IsDir() {
+			continue
+		}
+
+		session := s.getSession(e.Name())
+
+		// Sessions which only point to a different session are not listed
+		if session.Measurements == 0 && session.Name == "" && session.ID != current {
+			continue
+		}
+
+		session.IsCurrent = session.ID == current
+		sessions = append(sessions, session)
+	}
+
+	slices.SortStableFunc(sessions, func(a, b *Session) int {
+		return b.UpdatedAt.Compare(a.UpdatedAt)
+	})
+
+	return sessions, nil
+}
+
+// Returns the ID of the session used by the current process.
+func (s *LocalStorage) CurrentSessionID() string {
+	return filepath.Base(s.currentSessionDir)
+}
+
+// Returns the ID of the session with the given ID or name.
+func (s *LocalStorage) FindSession(ref string) (string, error) {
+	if ref == "" {
+		return "", ErrSessionNotFound
+	}
+
+	if isValidName(ref) {
+		if info, err := os.Stat(filepath.Join(s.sessionsDir, ref)); err == nil && info.IsDir() {
+			return ref, nil
+		}
+	}
+
+	entries, err := os.ReadDir(s.sessionsDir)
+
+	if err != nil {
+		return "", err
+	}
+
+	for _, e := range entries {
+		if e.IsDir() && readSessionFile(filepath.Join(s.sessionsDir, e.Name(), sessionNameFileName)) == ref {
+			return e.Name(), nil
+		}
+	}
+
+	return "", ErrSessionNotFound
+}
+
+// Selects the session for the current process only.
+func (s *LocalStorage) SelectSession(ref string) error {
+	id, err := s.FindSession(ref)
+
+	if err != nil {
+		return err
+	}
+
+	s.currentSessionDir = filepath.Join(s.sessionsDir, id)
+
+	return nil
+}
+
+// Makes the current terminal use the session with the given ID or name. An empty ref restores the terminal's own session.
+func (s *LocalStorage) UseSession(ref string) error {
+	linkPath := filepath.Join(s.terminalSessionDir, sessionLinkFileName)
+
+	if ref == "" {
+		err := os.Remove(linkPath)
+
+		if err != nil && !errors.Is(err, fs.ErrNotExist) {
+			return err
+		}
+
+		s.currentSessionDir = s.terminalSessionDir
+
+		return nil
+	}
+
+	id, err := s.FindSession(ref)
+
+	if err != nil {
+		return err
+	}
+
+	if id == filepath.Base(s.terminalSessionDir) {
+		return s.UseSession("")
+	}
+
+	err = os.WriteFile(linkPath, []byte(id), 0644)
+
+	if err != nil {
+		return err
+	}
+
+	s.currentSessionDir = filepath.Join(s.sessionsDir, id)
+
+	return nil
+}
+
+// Sets the name of the current session.
+func (s *LocalStorage) NameSession(name string) error {
+	if !isValidName(name) {
+		return ErrInvalidSessionName
+	}
+
+	id, err := s.FindSession(name)
+
+	if err == nil && id != s.CurrentSessionID() {
+		return ErrSessionNameTaken
+	}
+
+	return os.WriteFile(s.joinSessionDir(sessionNameFileName), []byte(name), 0644)
+}
+
+// Removes the measurements and history of the session with the given ID.
+func (s *LocalStorage) ClearSession(id string) error {
+	dir := filepath.Join(s.sessionsDir, id)
+
+	for _, name := range []string{measurementsFileName, historyFileName} {
+		err := os.Remove(filepath.Join(dir, name))
+
+		if err != nil && !errors.Is(err, fs.ErrNotExist) {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (s *LocalStorage) getSession(id string) *Session {
+	dir := filepath.Join(s.sessionsDir, id)
+	session := &Session{
+		ID:   id,
+		Name: readSessionFile(filepath.Join(dir, sessionNameFileName)),
+	}
+
+	if info, err := os.Stat(dir); err == nil {
+		session.UpdatedAt = info.ModTime()
+	}
+
+	for _, name := range []string{measurementsFileName, historyFileName} {
+		info, err := os.Stat(filepath.Join(dir, name))
+
+		if err == nil && info.ModTime().After(session.UpdatedAt) {
+			session.UpdatedAt = info.ModTime()
+		}
+	}
+
+	b, err := os.ReadFile(filepath.Join(dir, measurementsFileName))
+
+	if err == nil {
+		session.Measurements = bytes.Count(b, []byte("\n"))
+	}
+
+	return session
+}
+
+// Returns the session linked to the terminal session, or the terminal session itself.
+func (s *LocalStorage) resolveSessionDir(terminalSessionDir string) string {
+	id := readSessionFile(filepath.Join(terminalSessionDir, sessionLinkFileName))
+
+	if id == "" || !isValidName(id) {
+		return terminalSessionDir
+	}
+
+	dir := filepath.Join(s.sessionsDir, id)
+
+	if info, err := os.Stat(dir); err != nil || !info.IsDir() {
+		return terminalSessionDir
+	}
+
+	return dir
+}
+
+func readSessionFile(path string) string {
+	b, err := os.ReadFile(path)
+
+	if err != nil {
+		return ""
+	}
+
+	return strings.TrimSpace(string(b))
+}
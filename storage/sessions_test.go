@@ -0,0 +1,79 @@
+package storage
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_Sessions(t *testing.T) {
+	_storage := createDefaultTestStorage(t)
+	terminalId := _storage.CurrentSessionID()
+	assert.NoError(t, _storage.SaveIdToSession("id1"))
+
+	otherDir := filepath.Join(_storage.sessionsDir, "1_2")
+	assert.NoError(t, os.MkdirAll(otherDir, 0755))
+	assert.NoError(t, os.WriteFile(filepath.Join(otherDir, measurementsFileName), []byte("id2\nid3\n"), 0644))
+	assert.NoError(t, os.MkdirAll(filepath.Join(_storage.sessionsDir, "3_4"), 0755))
+
+	sessions, err := _storage.GetSessions()
+	assert.NoError(t, err)
+	assert.Len(t, sessions, 2)
+
+	assert.ErrorIs(t, _storage.NameSession("my incident"), ErrInvalidSessionName)
+	assert.NoError(t, _storage.NameSession("incident"))
+
+	id, err := _storage.FindSession("incident")
+	assert.NoError(t, err)
+	assert.Equal(t, terminalId, id)
+
+	id, err = _storage.FindSession("1_2")
+	assert.NoError(t, err)
+	assert.Equal(t, "1_2", id)
+
+	_, err = _storage.FindSession("unknown")
+	assert.ErrorIs(t, err, ErrSessionNotFound)
+
+	// Switch the terminal to a different session
+	assert.NoError(t, _storage.UseSession("1_2"))
+	assert.Equal(t, "1_2", _storage.CurrentSessionID())
+	assert.ErrorIs(t, _storage.NameSession("incident"), ErrSessionNameTaken)
+
+	mId, err := _storage.GetIdFromSession(-1)
+	assert.NoError(t, err)
+	assert.Equal(t, "id3", mId)
+
+	// A new process in the same terminal uses the linked session
+	assert.Equal(t, otherDir, _storage.resolveSessionDir(_storage.terminalSessionDir))
+
+	sessions, err = _storage.GetSessions()
+	assert.NoError(t, err)
+	assert.Len(t, sessions, 2)
+
+	for _, s := range sessions {
+		switch s.ID {
+		case terminalId:
+			assert.Equal(t, "incident", s.Name)
+			assert.Equal(t, 1, s.Measurements)
+			assert.False(t, s.IsCurrent)
+		case "1_2":
+			assert.Equal(t, 2, s.Measurements)
+			assert.True(t, s.IsCurrent)
+		default:
+			t.Errorf("unexpected session %s", s.ID)
+		}
+	}
+
+	assert.NoError(t, _storage.ClearSession("1_2"))
+	_, err = _storage.GetIdFromSession(-1)
+	assert.ErrorIs(t, err, ErrNoPreviousMeasurements)
+
+	assert.NoError(t, _storage.UseSession(""))
+	assert.Equal(t, terminalId, _storage.CurrentSessionID())
+	assert.Equal(t, _storage.terminalSessionDir, _storage.resolveSessionDir(_storage.terminalSessionDir))
+
+	assert.NoError(t, _storage.SelectSession("incident"))
+	assert.Equal(t, terminalId, _storage.CurrentSessionID())
+}
@@ -0,0 +1,56 @@
+package storage
+
+import (
+	"errors"
+	"io/fs"
+	"os"
+
+	"gopkg.in/yaml.v3"
+)
+
+var (
+	settingsFileName = "settings.yaml"
+)
+
+// Settings are user-defined defaults, stored as flat key-value pairs.
+type Settings map[string]string
+
+// Returns the settings stored in the settings file. A missing file results in empty settings.
+func (s *LocalStorage) LoadSettings() (Settings, error) {
+	settings := Settings{}
+	b, err := os.ReadFile(s.settingsPath())
+
+	if err != nil {
+		if errors.Is(err, fs.ErrNotExist) {
+			return settings, nil
+		}
+
+		return nil, err
+	}
+
+	err = yaml.Unmarshal(b, &settings)
+
+	if err != nil {
+		return nil, err
+	}
+
+	if settings == nil {
+		settings = Settings{}
+	}
+
+	return settings, nil
+}
+
+func (s *LocalStorage) SaveSettings(settings Settings) error {
+	b, err := yaml.Marshal(settings)
+
+	if err != nil {
+		return err
+	}
+
+	return os.WriteFile(s.settingsPath(), b, 0644)
+}
+
+func (s *LocalStorage) settingsPath() string {
+	return s.joinConfigDir(settingsFileName)
+}
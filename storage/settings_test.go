@@ -0,0 +1,31 @@
+package storage
+
+import (
+	"os"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_Settings(t *testing.T) {
+	_storage := createDefaultTestStorage(t)
+
+	settings, err := _storage.LoadSettings()
+	assert.NoError(t, err)
+	assert.Equal(t, Settings{}, settings)
+
+	settings["from"] = "Europe"
+	settings["limit"] = "3"
+	assert.NoError(t, _storage.SaveSettings(settings))
+
+	b, err := os.ReadFile(_storage.settingsPath())
+	assert.NoError(t, err)
+	assert.Equal(t, `from: Europe
+limit: "3"
+`, string(b))
+
+	assert.NoError(t, os.WriteFile(_storage.settingsPath(), []byte("from: Berlin\nlimit: 2\nci: true\n"), 0644))
+	settings, err = _storage.LoadSettings()
+	assert.NoError(t, err)
+	assert.Equal(t, Settings{"from": "Berlin", "limit": "2", "ci": "true"}, settings)
+}
@@ -19,10 +19,19 @@ type LocalStorage struct {
 	configName string
 	configDir  string
 
-	tempDir           string
-	sessionsDir       string
-	currentSessionDir string
-	config            *Config
+	tempDir            string
+	sessionsDir        string
+	terminalSessionDir string // Session of the parent process
+	currentSessionDir  string // Session in use, may differ from the terminal session
+	config             *Config
+	profile            string // Overrides config.Profile for the current process
+
+	tokenPassphrase string
+	tokenKeyFile    string
+	tokenSalt       []byte
+	tokenKeys       map[string][]byte // Derived keys by salt
+	tokenErrors     []error           // Decryption errors of the last loaded config
+	plaintextTokens bool              // The config file contains unencrypted tokens
 
 	migrations []MigrationFunc
 }
@@ -38,6 +47,8 @@ func (s *LocalStorage) Init(dirName string) error {
 	s.migrations = []MigrationFunc{
 		s.UpdateSessionDir,
 		s.MoveSessionsToUserDir,
+		s.RestrictConfigPermissions,
+		s.EncryptStoredTokens,
 	}
 
 	homeDir, err := os.UserHomeDir()
@@ -47,7 +58,7 @@ func (s *LocalStorage) Init(dirName string) error {
 	}
 
 	s.configDir = filepath.Join(homeDir, dirName)
-	err = os.MkdirAll(s.configDir, 0755)
+	err = os.MkdirAll(s.configDir, 0700)
 
 	if err != nil {
 		return err
@@ -56,19 +67,21 @@ func (s *LocalStorage) Init(dirName string) error {
 	userId := getUserID()
 	s.tempDir = filepath.Join(os.TempDir(), dirName+"-"+userId)
 	s.sessionsDir = filepath.Join(s.tempDir, "sessions")
-	s.currentSessionDir = filepath.Join(s.sessionsDir, getSessionId())
-	err = os.MkdirAll(s.currentSessionDir, 0755)
+	s.terminalSessionDir = filepath.Join(s.sessionsDir, getSessionId())
+	err = os.MkdirAll(s.terminalSessionDir, 0755)
 
 	if err != nil {
 		return err
 	}
 
+	s.currentSessionDir = s.resolveSessionDir(s.terminalSessionDir)
+
 	_, err = s.LoadConfig()
 
 	if err != nil {
 		if os.IsNotExist(err) {
 			s.config = &Config{
-				Profile:       "default",
+				Profile:       DefaultProfileName,
 				Profiles:      make(map[string]*Profile),
 				LastMigration: len(s.migrations),
 			}
@@ -109,12 +122,23 @@ func (s *LocalStorage) Cleanup() error {
 		return err
 	}
 
+	// Sessions with bookmarked measurements are kept
+	bookmarkedSessions := make(map[string]bool)
+
+	for _, b := range s.getBookmarksById() {
+		bookmarkedSessions[b.Session] = true
+	}
+
 	maxEntries := 100
 	l := len(entries)
 
 	for i, e := range entries {
 		name := e.Name()
 
+		if bookmarkedSessions[name] {
+			continue
+		}
+
 		if l-i > maxEntries {
 			_ = os.RemoveAll(filepath.Join(s.sessionsDir, name))
 
@@ -0,0 +1,194 @@
+package storage
+
+import (
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/hkdf"
+	"crypto/pbkdf2"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+)
+
+var (
+	ErrInvalidEncryptedToken = errors.New("invalid encrypted token")
+	ErrTokenKeyNotFound      = errors.New("token key not found")
+)
+
+const (
+	encryptedTokenPrefix = "v1:"
+	tokenSaltSize        = 16
+	tokenKeySize         = 32
+	passphraseIterations = 600_000
+)
+
+// Sets the source of the key used to encrypt the stored tokens. A passphrase takes precedence over a key file.
+// If neither is set, the tokens are stored unencrypted, protected only by the permissions of the config file.
+func (s *LocalStorage) SetTokenKey(passphrase string, keyFile string) {
+	s.tokenPassphrase = passphrase
+	s.tokenKeyFile = keyFile
+	s.tokenKeys = nil
+}
+
+// Returns the errors of the tokens which could not be decrypted when the config was loaded.
+func (s *LocalStorage) TokenErrors() []error {
+	return s.tokenErrors
+}
+
+// A key stored next to the config file doesn't protect the tokens, so encryption requires a passphrase or a key file.
+func (s *LocalStorage) tokenEncryptionEnabled() bool {
+	return s.tokenPassphrase != "" || s.tokenKeyFile != ""
+}
+
+// Encrypts the tokens which were stored in plaintext before token encryption was supported, if a token key is set.
+func (s *LocalStorage) EncryptStoredTokens() error {
+	if !s.tokenEncryptionEnabled() || !s.plaintextTokens {
+		return nil
+	}
+
+	return s.SaveConfig()
+}
+
+// Encrypts the token with AES-256-GCM. The result contains the key derivation salt and the nonce.
+func (s *LocalStorage) encryptToken(token *Token) (string, error) {
+	if s.tokenSalt == nil {
+		s.tokenSalt = make([]byte, tokenSaltSize)
+		_, _ = rand.Read(s.tokenSalt)
+	}
+
+	aead, err := s.tokenCipher(s.tokenSalt)
+
+	if err != nil {
+		return "", err
+	}
+
+	plaintext, err := json.Marshal(token)
+
+	if err != nil {
+		return "", err
+	}
+
+	nonce := make([]byte, aead.NonceSize())
+	_, _ = rand.Read(nonce)
+	b := append([]byte{}, s.tokenSalt...)
+	b = append(b, nonce...)
+	b = aead.Seal(b, nonce, plaintext, nil)
+
+	return encryptedTokenPrefix + base64.RawStdEncoding.EncodeToString(b), nil
+}
+
+func (s *LocalStorage) decryptToken(encrypted string) (*Token, error) {
+	data, ok := strings.CutPrefix(encrypted, encryptedTokenPrefix)
+
+	if !ok {
+		return nil, ErrInvalidEncryptedToken
+	}
+
+	b, err := base64.RawStdEncoding.DecodeString(data)
+
+	if err != nil || len(b) < tokenSaltSize {
+		return nil, ErrInvalidEncryptedToken
+	}
+
+	salt := b[:tokenSaltSize]
+	aead, err := s.tokenCipher(salt)
+
+	if err != nil {
+		return nil, err
+	}
+
+	b = b[tokenSaltSize:]
+
+	if len(b) < aead.NonceSize() {
+		return nil, ErrInvalidEncryptedToken
+	}
+
+	plaintext, err := aead.Open(nil, b[:aead.NonceSize()], b[aead.NonceSize():], nil)
+
+	if err != nil {
+		return nil, fmt.Errorf("%w: wrong key or corrupted data", ErrInvalidEncryptedToken)
+	}
+
+	token := &Token{}
+	err = json.Unmarshal(plaintext, token)
+
+	if err != nil {
+		return nil, err
+	}
+
+	// Reuse the salt so that the key doesn't have to be derived again on save
+	s.tokenSalt = salt
+
+	return token, nil
+}
+
+// Returns the cipher for the key derived with the given salt. Derived keys are cached, as passphrases are expensive to derive.
+func (s *LocalStorage) tokenCipher(salt []byte) (cipher.AEAD, error) {
+	key := s.tokenKeys[string(salt)]
+
+	if key == nil {
+		secret, err := s.loadTokenSecret()
+
+		if err != nil {
+			return nil, err
+		}
+
+		if s.tokenPassphrase != "" {
+			key, err = pbkdf2.Key(sha256.New, string(secret), salt, passphraseIterations, tokenKeySize)
+		} else {
+			key, err = hkdf.Key(sha256.New, secret, salt, "globalping-cli token", tokenKeySize)
+		}
+
+		if err != nil {
+			return nil, err
+		}
+
+		if s.tokenKeys == nil {
+			s.tokenKeys = make(map[string][]byte)
+		}
+
+		s.tokenKeys[string(salt)] = key
+	}
+
+	block, err := aes.NewCipher(key)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return cipher.NewGCM(block)
+}
+
+// Returns the passphrase or the contents of the key file.
+func (s *LocalStorage) loadTokenSecret() ([]byte, error) {
+	if s.tokenPassphrase != "" {
+		return []byte(s.tokenPassphrase), nil
+	}
+
+	if s.tokenKeyFile == "" {
+		return nil, fmt.Errorf("%w: set GLOBALPING_TOKEN_KEY or GLOBALPING_TOKEN_KEY_FILE", ErrTokenKeyNotFound)
+	}
+
+	b, err := os.ReadFile(s.tokenKeyFile)
+
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, fmt.Errorf("%w: %s", ErrTokenKeyNotFound, s.tokenKeyFile)
+		}
+
+		return nil, err
+	}
+
+	secret := []byte(strings.TrimSpace(string(b)))
+
+	if len(secret) == 0 {
+		return nil, fmt.Errorf("%w: %s is empty", ErrTokenKeyNotFound, s.tokenKeyFile)
+	}
+
+	return secret, nil
+}
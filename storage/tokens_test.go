@@ -0,0 +1,135 @@
+package storage
+
+import (
+	"os"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_Tokens_Passphrase(t *testing.T) {
+	_storage := createDefaultTestStorage(t)
+	_storage.SetTokenKey("correct horse battery staple", "")
+	_storage.GetProfile().Token = &Token{AccessToken: "token", RefreshToken: "refresh"}
+	assert.NoError(t, _storage.SaveConfig())
+
+	reloaded := NewLocalStorage(nil)
+	reloaded.configDir = _storage.configDir
+	reloaded.configName = _storage.configName
+	reloaded.SetTokenKey("wrong passphrase", "")
+	config, err := reloaded.LoadConfig()
+	assert.NoError(t, err)
+	assert.Nil(t, config.Profiles["default"].Token)
+	assert.Len(t, reloaded.TokenErrors(), 1)
+	assert.ErrorIs(t, reloaded.TokenErrors()[0], ErrInvalidEncryptedToken)
+	assert.EqualError(t, reloaded.TokenErrors()[0], "failed to decrypt the token of profile default: invalid encrypted token: wrong key or corrupted data")
+
+	// The token is kept encrypted until the correct key is set
+	encrypted := config.Profiles["default"].EncryptedToken
+	assert.NoError(t, reloaded.SaveConfig())
+
+	reloaded = NewLocalStorage(nil)
+	reloaded.configDir = _storage.configDir
+	reloaded.configName = _storage.configName
+	reloaded.SetTokenKey("correct horse battery staple", "")
+	config, err = reloaded.LoadConfig()
+	assert.NoError(t, err)
+	assert.Equal(t, &Token{AccessToken: "token", RefreshToken: "refresh"}, config.Profiles["default"].Token)
+	assert.NotEmpty(t, encrypted)
+}
+
+func Test_Tokens_KeyFile(t *testing.T) {
+	_storage := createDefaultTestStorage(t)
+	keyFile := _storage.joinConfigDir("custom.key")
+	_storage.SetTokenKey("", keyFile)
+	_storage.GetProfile().Token = &Token{AccessToken: "token"}
+	assert.ErrorIs(t, _storage.SaveConfig(), ErrTokenKeyNotFound)
+
+	assert.NoError(t, os.WriteFile(keyFile, []byte("a5HxZ3pLxq2yVwGk7bN9cR4tE1uI8oP6\n"), 0600))
+	assert.NoError(t, _storage.SaveConfig())
+
+	reloaded := NewLocalStorage(nil)
+	reloaded.configDir = _storage.configDir
+	reloaded.configName = _storage.configName
+	reloaded.SetTokenKey("", keyFile)
+	config, err := reloaded.LoadConfig()
+	assert.NoError(t, err)
+	assert.Equal(t, &Token{AccessToken: "token"}, config.Profiles["default"].Token)
+}
+
+func Test_Tokens_NoKey(t *testing.T) {
+	_storage := createDefaultTestStorage(t)
+	_storage.SetTokenKey("correct horse battery staple", "")
+	_storage.GetProfile().Token = &Token{AccessToken: "token"}
+	assert.NoError(t, _storage.SaveConfig())
+
+	reloaded := NewLocalStorage(nil)
+	reloaded.configDir = _storage.configDir
+	reloaded.configName = _storage.configName
+	config, err := reloaded.LoadConfig()
+	assert.NoError(t, err)
+	assert.Nil(t, config.Profiles["default"].Token)
+	assert.Len(t, reloaded.TokenErrors(), 1)
+	assert.ErrorIs(t, reloaded.TokenErrors()[0], ErrTokenKeyNotFound)
+}
+
+func Test_Migrate_EncryptStoredTokens(t *testing.T) {
+	_storage := createDefaultTestStorage(t)
+	path := _storage.joinConfigDir(_storage.configName)
+	config := `{"profile":"default","profiles":{"default":{"token":{"access_token":"token","refresh_token":"refresh"}}},"last_migration":3}`
+	assert.NoError(t, os.WriteFile(path, []byte(config), 0600))
+
+	// Nothing to encrypt with until a token key is set
+	reloaded := NewLocalStorage(nil)
+	reloaded.configDir = _storage.configDir
+	reloaded.configName = _storage.configName
+	_, err := reloaded.LoadConfig()
+	assert.NoError(t, err)
+	assert.NoError(t, reloaded.EncryptStoredTokens())
+
+	b, err := os.ReadFile(path)
+	assert.NoError(t, err)
+	assert.Contains(t, string(b), "refresh")
+
+	assert.NoError(t, os.WriteFile(path, []byte(config), 0600))
+	reloaded = NewLocalStorage(nil)
+	reloaded.configDir = _storage.configDir
+	reloaded.configName = _storage.configName
+	reloaded.SetTokenKey("correct horse battery staple", "")
+	reloaded.migrations = []MigrationFunc{nil, nil, nil, reloaded.EncryptStoredTokens}
+	_, err = reloaded.LoadConfig()
+	assert.NoError(t, err)
+	assert.NoError(t, reloaded.Migrate())
+
+	b, err = os.ReadFile(path)
+	assert.NoError(t, err)
+	assert.NotContains(t, string(b), "refresh")
+	assert.Contains(t, string(b), `"encrypted_token":"v1:`)
+	assert.Contains(t, string(b), `"last_migration":4`)
+	assert.False(t, reloaded.plaintextTokens)
+	assert.Equal(t, &Token{AccessToken: "token", RefreshToken: "refresh"}, reloaded.GetProfile().Token)
+}
+
+func Test_Migrate_RestrictConfigPermissions(t *testing.T) {
+	_storage := createDefaultTestStorage(t)
+	path := _storage.joinConfigDir(_storage.configName)
+	assert.NoError(t, os.WriteFile(path, []byte(`{"profile":"default","profiles":{},"last_migration":2}`), 0644))
+	assert.NoError(t, os.Chmod(path, 0644))
+	assert.NoError(t, os.Chmod(_storage.configDir, 0755))
+
+	reloaded := NewLocalStorage(nil)
+	reloaded.configDir = _storage.configDir
+	reloaded.configName = _storage.configName
+	reloaded.migrations = _storage.migrations
+	_, err := reloaded.LoadConfig()
+	assert.NoError(t, err)
+	assert.NoError(t, reloaded.Migrate())
+
+	info, err := os.Stat(path)
+	assert.NoError(t, err)
+	assert.Equal(t, os.FileMode(0600), info.Mode().Perm())
+
+	info, err = os.Stat(_storage.configDir)
+	assert.NoError(t, err)
+	assert.Equal(t, os.FileMode(0700), info.Mode().Perm())
+}
@@ -1,7 +1,11 @@
 package utils
 
 import (
+	"errors"
+	"fmt"
+	"net/url"
 	"os"
+	"strings"
 	_time "time"
 )
 
@@ -9,9 +13,25 @@ var ShareURL = "https://globalping.io?measurement="
 
 type Config struct {
 	GlobalpingToken            string
+	GlobalpingProfile          string
+	GlobalpingSession          string
 	GlobalpingAuthClientID     string
 	GlobalpingAuthClientSecret string
 	GlobalpingAPIInterval      _time.Duration
+	GlobalpingAPIURL           string // Defaults to the globalping-go API URL if empty
+	GlobalpingAuthURL          string
+	GlobalpingDashboardURL     string
+	GlobalpingShareURL         string
+	GlobalpingProxy            string
+	GlobalpingCACert           string
+	GlobalpingClientCert       string
+	GlobalpingClientKey        string
+	GlobalpingDebug            bool // Log API requests and responses
+	GlobalpingDebugBodies      bool // Include the bodies in the debug log
+	GlobalpingDebugFile        string
+	GlobalpingRecordDir        string
+	GlobalpingReplayDir        string
+	GlobalpingReleasesURL      string // Defaults to the GitHub releases of the CLI if empty
 }
 
 func NewConfig() *Config {
@@ -22,6 +42,88 @@ func NewConfig() *Config {
 	}
 }
 
-func (c *Config) Load() {
+// Loads the config from the environment. Returns an error if an endpoint URL is invalid.
+func (c *Config) Load() error {
 	c.GlobalpingToken = os.Getenv("GLOBALPING_TOKEN")
+	c.GlobalpingProfile = os.Getenv("GLOBALPING_PROFILE")
+	c.GlobalpingSession = os.Getenv("GLOBALPING_SESSION")
+
+	// Endpoints can also be set in the settings file, so they are only overridden when the variable is set
+	endpoints := []struct {
+		name  string
+		value *string
+		trim  bool
+	}{
+		{"GLOBALPING_API_URL", &c.GlobalpingAPIURL, true},
+		{"GLOBALPING_AUTH_URL", &c.GlobalpingAuthURL, true},
+		{"GLOBALPING_DASHBOARD_URL", &c.GlobalpingDashboardURL, true},
+		{"GLOBALPING_SHARE_URL", &c.GlobalpingShareURL, false},
+	}
+
+	for _, e := range endpoints {
+		v := os.Getenv(e.name)
+
+		if v == "" {
+			continue
+		}
+
+		v, err := ParseEndpointURL(v)
+
+		if err != nil {
+			return fmt.Errorf("invalid %s: %w", e.name, err)
+		}
+
+		if e.trim {
+			v = strings.TrimRight(v, "/")
+		}
+
+		*e.value = v
+	}
+
+	if v := os.Getenv("GLOBALPING_RELEASES_URL"); v != "" {
+		c.GlobalpingReleasesURL = v
+	}
+
+	if v := os.Getenv("GLOBALPING_PROXY"); v != "" {
+		c.GlobalpingProxy = v
+	}
+
+	if v := os.Getenv("GLOBALPING_CA_CERT"); v != "" {
+		c.GlobalpingCACert = v
+	}
+
+	if v := os.Getenv("GLOBALPING_CLIENT_CERT"); v != "" {
+		c.GlobalpingClientCert = v
+	}
+
+	if v := os.Getenv("GLOBALPING_CLIENT_KEY"); v != "" {
+		c.GlobalpingClientKey = v
+	}
+
+	// GLOBALPING_DEBUG=bodies also logs the request and response bodies
+	switch v := strings.ToLower(os.Getenv("GLOBALPING_DEBUG")); v {
+	case "", "0", "false":
+	case "bodies":
+		c.GlobalpingDebug = true
+		c.GlobalpingDebugBodies = true
+	default:
+		c.GlobalpingDebug = true
+	}
+
+	if v := os.Getenv("GLOBALPING_DEBUG_FILE"); v != "" {
+		c.GlobalpingDebugFile = v
+	}
+
+	return nil
+}
+
+// Returns the value if it's an absolute http or https URL.
+func ParseEndpointURL(value string) (string, error) {
+	u, err := url.Parse(value)
+
+	if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+		return "", errors.New("must be an absolute http or https URL")
+	}
+
+	return value, nil
 }
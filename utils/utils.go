@@ -2,7 +2,9 @@ package utils
 
 import (
 	"errors"
+	"io"
 	"math"
+	"os"
 	"os/exec"
 	"runtime"
 	_time "time"
@@ -11,6 +13,7 @@ import (
 type Utils interface {
 	Now() _time.Time
 	OpenBrowser(url string) error
+	RunCommand(command string, env []string, out io.Writer) error
 }
 
 type utils struct{}
@@ -43,6 +46,23 @@ func (*utils) OpenBrowser(url string) error {
 	}
 }
 
+// Runs the command with the shell of the platform, adding the variables to the environment.
+func (*utils) RunCommand(command string, env []string, out io.Writer) error {
+	var cmd *exec.Cmd
+
+	if runtime.GOOS == "windows" {
+		cmd = exec.Command("cmd", "/C", command)
+	} else {
+		cmd = exec.Command("sh", "-c", command)
+	}
+
+	cmd.Env = append(os.Environ(), env...)
+	cmd.Stdout = out
+	cmd.Stderr = out
+
+	return cmd.Run()
+}
+
 func FormatSeconds(seconds int64) string {
 	if seconds < 60 {
 		return Pluralize(seconds, "second")
@@ -0,0 +1,341 @@
+package version
+
+import (
+	"archive/tar"
+	"archive/zip"
+	"bufio"
+	"bytes"
+	"compress/gzip"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"runtime"
+	"strings"
+)
+
+const DefaultReleasesURL = "https://api.github.com/repos/jsdelivr/globalping-cli/releases/latest"
+
+var (
+	ErrAssetNotFound    = errors.New("no release asset for this platform")
+	ErrChecksumMismatch = errors.New("checksum mismatch")
+)
+
+type InstallMethod string
+
+const (
+	InstallMethodStandalone InstallMethod = "standalone"
+	InstallMethodHomebrew   InstallMethod = "Homebrew"
+	InstallMethodSnap       InstallMethod = "Snap"
+	InstallMethodDeb        InstallMethod = "deb"
+	InstallMethodRPM        InstallMethod = "rpm"
+	InstallMethodChocolatey InstallMethod = "Chocolatey"
+	InstallMethodWinGet     InstallMethod = "WinGet"
+)
+
+// The commands which upgrade the packaged installs.
+var upgradeCommands = map[InstallMethod]string{
+	InstallMethodHomebrew:   "brew upgrade globalping",
+	InstallMethodSnap:       "sudo snap refresh globalping",
+	InstallMethodDeb:        "sudo apt update && sudo apt install --only-upgrade globalping",
+	InstallMethodRPM:        "sudo dnf upgrade globalping",
+	InstallMethodChocolatey: "choco upgrade globalping",
+	InstallMethodWinGet:     "winget upgrade jsdelivr.globalping",
+}
+
+type Release struct {
+	TagName string  `json:"tag_name"`
+	URL     string  `json:"html_url"`
+	Assets  []Asset `json:"assets"`
+}
+
+type Asset struct {
+	Name string `json:"name"`
+	URL  string `json:"browser_download_url"`
+}
+
+func (r *Release) Version() string {
+	return strings.TrimPrefix(r.TagName, "v")
+}
+
+type Updater struct {
+	http        *http.Client
+	releasesURL string
+	executable  string
+}
+
+// Creates an updater for the executable, which gets the releases from the GitHub API compatible URL.
+func NewUpdater(httpClient *http.Client, releasesURL string, executable string) *Updater {
+	if releasesURL == "" {
+		releasesURL = DefaultReleasesURL
+	}
+
+	return &Updater{
+		http:        httpClient,
+		releasesURL: releasesURL,
+		executable:  executable,
+	}
+}
+
+func (u *Updater) LatestRelease(ctx context.Context) (*Release, error) {
+	b, err := u.get(ctx, u.releasesURL)
+
+	if err != nil {
+		return nil, fmt.Errorf("failed to get the latest release: %w", err)
+	}
+
+	release := &Release{}
+	err = json.Unmarshal(b, release)
+
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse the latest release: %w", err)
+	}
+
+	return release, nil
+}
+
+// Returns how the CLI was installed, based on the path of the executable.
+func (u *Updater) InstallMethod() InstallMethod {
+	path := filepath.ToSlash(u.executable)
+
+	switch {
+	case strings.Contains(path, "/Cellar/") || strings.Contains(path, "/homebrew/"):
+		return InstallMethodHomebrew
+	case strings.HasPrefix(path, "/snap/"):
+		return InstallMethodSnap
+	case strings.Contains(strings.ToLower(path), "/chocolatey/"):
+		return InstallMethodChocolatey
+	case strings.Contains(path, "/WinGet/"):
+		return InstallMethodWinGet
+	case path == "/usr/bin/globalping":
+		if _, err := os.Stat("/var/lib/dpkg/info/globalping.list"); err == nil {
+			return InstallMethodDeb
+		}
+
+		if exec.Command("rpm", "-q", "globalping").Run() == nil {
+			return InstallMethodRPM
+		}
+	}
+
+	return InstallMethodStandalone
+}
+
+// Returns the command which upgrades a packaged install, or an empty string for standalone installs.
+func (u *Updater) UpgradeCommand() string {
+	return upgradeCommands[u.InstallMethod()]
+}
+
+// Downloads the release for this platform, verifies its checksum, and replaces the executable.
+func (u *Updater) Upgrade(ctx context.Context, release *Release) error {
+	name := AssetName(runtime.GOOS, runtime.GOARCH)
+	asset, checksums := findAsset(release, name), findAsset(release, "checksums.txt")
+
+	if asset == nil || checksums == nil {
+		return fmt.Errorf("%w: %s", ErrAssetNotFound, name)
+	}
+
+	b, err := u.get(ctx, checksums.URL)
+
+	if err != nil {
+		return fmt.Errorf("failed to download the checksums: %w", err)
+	}
+
+	checksum := findChecksum(b, name)
+	archive, err := u.get(ctx, asset.URL)
+
+	if err != nil {
+		return fmt.Errorf("failed to download %s: %w", name, err)
+	}
+
+	sum := sha256.Sum256(archive)
+
+	if checksum == "" || hex.EncodeToString(sum[:]) != checksum {
+		return fmt.Errorf("%w: %s", ErrChecksumMismatch, name)
+	}
+
+	binary, err := extractBinary(archive, strings.HasSuffix(name, ".zip"))
+
+	if err != nil {
+		return fmt.Errorf("failed to extract %s: %w", name, err)
+	}
+
+	return replaceExecutable(u.executable, binary)
+}
+
+// Returns the name of the release archive, as set in .goreleaser.yaml.
+func AssetName(goos string, goarch string) string {
+	arch := goarch
+
+	switch goarch {
+	case "amd64":
+		arch = "x86_64"
+	case "386":
+		arch = "i386"
+	case "arm":
+		arch = "armv6"
+	}
+
+	ext := ".tar.gz"
+
+	if goos == "windows" {
+		ext = ".zip"
+	}
+
+	return "globalping_" + strings.ToUpper(goos[:1]) + goos[1:] + "_" + arch + ext
+}
+
+func (u *Updater) get(ctx context.Context, url string) ([]byte, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+
+	if err != nil {
+		return nil, err
+	}
+
+	req.Header.Set("User-Agent", "globalping-cli/v"+Version)
+	resp, err := u.http.Do(req)
+
+	if err != nil {
+		return nil, err
+	}
+
+	defer func() {
+		_ = resp.Body.Close()
+	}()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("unexpected status %s", resp.Status)
+	}
+
+	return io.ReadAll(resp.Body)
+}
+
+func findAsset(release *Release, name string) *Asset {
+	for i := range release.Assets {
+		if release.Assets[i].Name == name {
+			return &release.Assets[i]
+		}
+	}
+
+	return nil
+}
+
+// Returns the checksum of the file from a sha256sum formatted list.
+func findChecksum(b []byte, name string) string {
+	scanner := bufio.NewScanner(bytes.NewReader(b))
+
+	for scanner.Scan() {
+		fields := strings.Fields(scanner.Text())
+
+		if len(fields) == 2 && fields[1] == name {
+			return strings.ToLower(fields[0])
+		}
+	}
+
+	return ""
+}
+
+func extractBinary(archive []byte, isZip bool) ([]byte, error) {
+	if isZip {
+		zr, err := zip.NewReader(bytes.NewReader(archive), int64(len(archive)))
+
+		if err != nil {
+			return nil, err
+		}
+
+		for _, f := range zr.File {
+			if filepath.Base(f.Name) == "globalping.exe" {
+				rc, err := f.Open()
+
+				if err != nil {
+					return nil, err
+				}
+
+				defer func() {
+					_ = rc.Close()
+				}()
+
+				return io.ReadAll(rc)
+			}
+		}
+
+		return nil, errors.New("globalping.exe not found in the archive")
+	}
+
+	gr, err := gzip.NewReader(bytes.NewReader(archive))
+
+	if err != nil {
+		return nil, err
+	}
+
+	tr := tar.NewReader(gr)
+
+	for {
+		h, err := tr.Next()
+
+		if err != nil {
+			if errors.Is(err, io.EOF) {
+				return nil, errors.New("globalping not found in the archive")
+			}
+
+			return nil, err
+		}
+
+		if h.Typeflag == tar.TypeReg && filepath.Base(h.Name) == "globalping" {
+			return io.ReadAll(tr)
+		}
+	}
+}
+
+// Writes the new binary next to the executable and renames it over the old one, so that it's replaced atomically.
+// Windows doesn't allow replacing a running executable, so the old one is moved aside first.
+func replaceExecutable(executable string, binary []byte) error {
+	dir := filepath.Dir(executable)
+	f, err := os.CreateTemp(dir, ".globalping-upgrade-*")
+
+	if err != nil {
+		return fmt.Errorf("failed to write to %s: %w", dir, err)
+	}
+
+	tmp := f.Name()
+	_, err = f.Write(binary)
+
+	if closeErr := f.Close(); err == nil {
+		err = closeErr
+	}
+
+	if err == nil {
+		err = os.Chmod(tmp, 0755)
+	}
+
+	if err != nil {
+		_ = os.Remove(tmp)
+
+		return fmt.Errorf("failed to write the new executable: %w", err)
+	}
+
+	if runtime.GOOS == "windows" {
+		old := executable + ".old"
+		_ = os.Remove(old)
+
+		if err := os.Rename(executable, old); err != nil {
+			_ = os.Remove(tmp)
+
+			return fmt.Errorf("failed to replace the executable: %w", err)
+		}
+	}
+
+	if err := os.Rename(tmp, executable); err != nil {
+		_ = os.Remove(tmp)
+
+		return fmt.Errorf("failed to replace the executable: %w", err)
+	}
+
+	return nil
+}
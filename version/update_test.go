@@ -0,0 +1,129 @@
+package version
+
+import (
+	"archive/tar"
+	"bytes"
+	"compress/gzip"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"runtime"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_AssetName(t *testing.T) {
+	assert.Equal(t, "globalping_Linux_x86_64.tar.gz", AssetName("linux", "amd64"))
+	assert.Equal(t, "globalping_Darwin_arm64.tar.gz", AssetName("darwin", "arm64"))
+	assert.Equal(t, "globalping_Windows_i386.zip", AssetName("windows", "386"))
+}
+
+func Test_Updater_Upgrade(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("the test archive is a tarball")
+	}
+
+	archive := createArchive(t, "globalping", []byte("new binary"))
+	sum := sha256.Sum256(archive)
+	server := createReleaseServer(t, archive, hex.EncodeToString(sum[:]))
+
+	executable := filepath.Join(t.TempDir(), "globalping")
+	assert.NoError(t, os.WriteFile(executable, []byte("old binary"), 0755))
+
+	u := NewUpdater(server.Client(), server.URL+"/latest", executable)
+	assert.Equal(t, InstallMethodStandalone, u.InstallMethod())
+	assert.Equal(t, "", u.UpgradeCommand())
+
+	release, err := u.LatestRelease(t.Context())
+	assert.NoError(t, err)
+	assert.Equal(t, "1.5.0", release.Version())
+
+	err = u.Upgrade(t.Context(), release)
+	assert.NoError(t, err)
+
+	b, err := os.ReadFile(executable)
+	assert.NoError(t, err)
+	assert.Equal(t, "new binary", string(b))
+
+	info, err := os.Stat(executable)
+	assert.NoError(t, err)
+	assert.Equal(t, os.FileMode(0755), info.Mode().Perm())
+
+	entries, err := os.ReadDir(filepath.Dir(executable))
+	assert.NoError(t, err)
+	assert.Len(t, entries, 1)
+}
+
+func Test_Updater_Upgrade_Checksum_Mismatch(t *testing.T) {
+	archive := createArchive(t, "globalping", []byte("new binary"))
+	server := createReleaseServer(t, archive, "0000")
+
+	executable := filepath.Join(t.TempDir(), "globalping")
+	assert.NoError(t, os.WriteFile(executable, []byte("old binary"), 0755))
+
+	u := NewUpdater(server.Client(), server.URL+"/latest", executable)
+	release, err := u.LatestRelease(t.Context())
+	assert.NoError(t, err)
+
+	err = u.Upgrade(t.Context(), release)
+	assert.ErrorIs(t, err, ErrChecksumMismatch)
+
+	b, err := os.ReadFile(executable)
+	assert.NoError(t, err)
+	assert.Equal(t, "old binary", string(b))
+}
+
+func Test_Updater_InstallMethod(t *testing.T) {
+	u := NewUpdater(http.DefaultClient, "", "/opt/homebrew/Cellar/globalping/1.4.0/bin/globalping")
+	assert.Equal(t, InstallMethodHomebrew, u.InstallMethod())
+	assert.Equal(t, "brew upgrade globalping", u.UpgradeCommand())
+
+	u = NewUpdater(http.DefaultClient, "", "/snap/globalping/42/bin/globalping")
+	assert.Equal(t, InstallMethodSnap, u.InstallMethod())
+	assert.Equal(t, "sudo snap refresh globalping", u.UpgradeCommand())
+}
+
+func createArchive(t *testing.T, name string, content []byte) []byte {
+	b := new(bytes.Buffer)
+	gw := gzip.NewWriter(b)
+	tw := tar.NewWriter(gw)
+	assert.NoError(t, tw.WriteHeader(&tar.Header{Name: "README.md", Mode: 0644, Size: 2, Typeflag: tar.TypeReg}))
+	_, _ = tw.Write([]byte("hi"))
+	assert.NoError(t, tw.WriteHeader(&tar.Header{Name: name, Mode: 0755, Size: int64(len(content)), Typeflag: tar.TypeReg}))
+	_, _ = tw.Write(content)
+	assert.NoError(t, tw.Close())
+	assert.NoError(t, gw.Close())
+
+	return b.Bytes()
+}
+
+func createReleaseServer(t *testing.T, archive []byte, checksum string) *httptest.Server {
+	name := AssetName(runtime.GOOS, runtime.GOARCH)
+	mux := http.NewServeMux()
+	server := httptest.NewServer(mux)
+	t.Cleanup(server.Close)
+
+	mux.HandleFunc("/latest", func(w http.ResponseWriter, _ *http.Request) {
+		_ = json.NewEncoder(w).Encode(Release{
+			TagName: "v1.5.0",
+			URL:     "https://github.com/jsdelivr/globalping-cli/releases/tag/v1.5.0",
+			Assets: []Asset{
+				{Name: "checksums.txt", URL: server.URL + "/checksums.txt"},
+				{Name: name, URL: server.URL + "/" + name},
+			},
+		})
+	})
+	mux.HandleFunc("/checksums.txt", func(w http.ResponseWriter, _ *http.Request) {
+		_, _ = w.Write([]byte("abcd  globalping_Other_x86_64.tar.gz\n" + checksum + "  " + name + "\n"))
+	})
+	mux.HandleFunc("/"+name, func(w http.ResponseWriter, _ *http.Request) {
+		_, _ = w.Write(archive)
+	})
+
+	return server
+}
@@ -36,6 +36,13 @@ type Context struct {
 	Tail uint // Number of last measurements to show
 
 	APIMinInterval time.Duration // Minimum interval between API calls
+	Profile        string        // Name of the auth profile to use
+	TokenFromEnv   bool          // The token is provided by the GLOBALPING_TOKEN environment variable instead of the profile
+	Retry          int           // Number of retries on server and connection errors
+	WaitForCredits bool          // Wait for the rate limit to reset instead of failing
+	MaxCredits     int64         // Maximum number of credits to spend, 0 for no limit
+	OnComplete     string        // Command to run after each successful measurement
+	OnFailure      string        // Command to run after each measurement with failed tests
 
 	IsLocationFromSession bool // Determine whether the previous location is used
 	RecordToSession       bool // Record measurement to session history
@@ -45,6 +52,7 @@ type Context struct {
 	TableOutputRows     int
 	AggregatedStats     []*MeasurementStats
 	MeasurementsCreated int
+	CreditsSpent        int64          // Estimated number of credits spent by the created measurements
 	History             *HistoryBuffer // History of measurements
 	RunSessionStartedAt time.Time
 }
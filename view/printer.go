@@ -2,6 +2,7 @@ package view
 
 import (
 	"bufio"
+	"encoding/base64"
 	"errors"
 	"fmt"
 	"io"
@@ -57,8 +58,13 @@ type Printer struct {
 
 	areaHeight     int
 	disableStyling bool
+	width          int
+	height         int
+	errTerminal    *bool // Overrides the detection of a terminal on ErrWriter
 }
 
+var ErrClipboardNotTerminal = errors.New("can't copy to the clipboard, stderr is not a terminal")
+
 func NewPrinter(
 	inReader io.Reader,
 	outWriter io.Writer,
@@ -218,6 +224,10 @@ func (p *Printer) ReadPassword() (string, error) {
 }
 
 func (p *Printer) GetSize() (width, height int) {
+	if p.width > 0 && p.height > 0 {
+		return p.width, p.height
+	}
+
 	f, ok := p.OutWriter.(*os.File)
 
 	if !ok {
@@ -237,6 +247,12 @@ func (p *Printer) GetSize() (width, height int) {
 	return w, h
 }
 
+// Sets a fixed size to be returned by GetSize, e.g. when the output is rendered into a part of the screen.
+func (p *Printer) SetSize(width, height int) {
+	p.width = width
+	p.height = height
+}
+
 func (p *Printer) AreaUpdate(content *string) {
 	p.AreaClear()
 	p.areaHeight = strings.Count(*content, "\n")
@@ -255,3 +271,38 @@ func (p *Printer) AreaClear() {
 func (p *Printer) DisableStyling() {
 	p.disableStyling = true
 }
+
+// Returns true if ErrWriter is a terminal.
+func (p *Printer) IsErrTerminal() bool {
+	if p.errTerminal != nil {
+		return *p.errTerminal
+	}
+
+	f, ok := p.ErrWriter.(*os.File)
+
+	return ok && term.IsTerminal(int(f.Fd()))
+}
+
+// Sets whether ErrWriter is treated as a terminal, e.g. when it's a wrapper of the terminal.
+func (p *Printer) SetErrTerminal(isTerminal bool) {
+	p.errTerminal = &isTerminal
+}
+
+// Copies the text to the clipboard with the OSC 52 escape sequence, which is handled by the terminal, so it also works over SSH.
+// The sequence is written to stderr, so that it doesn't end up in redirected output. If stderr is redirected too, nothing is written.
+func (p *Printer) CopyToClipboard(s string) error {
+	if !p.IsErrTerminal() {
+		return ErrClipboardNotTerminal
+	}
+
+	seq := "\033]52;c;" + base64.StdEncoding.EncodeToString([]byte(s)) + "\a"
+
+	// tmux only passes the sequence to the terminal if it's wrapped
+	if os.Getenv("TMUX") != "" {
+		seq = "\033Ptmux;\033" + seq + "\033\\"
+	}
+
+	_, _ = fmt.Fprint(p.ErrWriter, seq)
+
+	return nil
+}